@@ -0,0 +1,96 @@
+// Command egodteam-query runs backlog queries against an event log, from the command line
+// or as an HTTP API:
+//
+//	egodteam-query -store events.jsonl status:Ready priority:High points:0
+//	egodteam-query -store events.jsonl -kind task type:Testing status:Blocked assignee:dev-2
+//	egodteam-query -store events.jsonl -serve :8080
+//
+// The HTTP API answers GET /query?kind=task&q=type:Testing+status:Blocked with the matching
+// entities as JSON.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+	"egodteam/internal/query"
+)
+
+func main() {
+	path := flag.String("store", "events.jsonl", "event log to query")
+	kind := flag.String("kind", string(models.KindStory), "entities to query: story, task or sprint")
+	asJSON := flag.Bool("json", false, "print the results as JSON instead of a table")
+	serve := flag.String("serve", "", "serve the HTTP API on this address instead of running one query")
+	flag.Parse()
+
+	if err := run(*path, models.EntityKind(*kind), strings.Join(flag.Args(), " "), *asJSON, *serve); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run opens the event log, which must exist, and either answers one query or serves the HTTP API
+func run(path string, kind models.EntityKind, input string, asJSON bool, serve string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err // Opening a missing log would create an empty one
+	}
+	events, err := store.Open(path)
+	if err != nil {
+		return err
+	}
+	defer events.Close()
+
+	if serve != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/query", query.Handler(events.State))
+		log.Printf("Serving queries over %s on %s", path, serve)
+		return http.ListenAndServe(serve, mux)
+	}
+
+	result, err := query.Select(events.State(), kind, input)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	return printTable(os.Stdout, result)
+}
+
+// printTable writes one line per entity with its ID, status and main fields
+func printTable(w io.Writer, result any) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	switch items := result.(type) {
+	case []*models.UserStory:
+		fmt.Fprintln(tw, "ID\tSTATUS\tPRIORITY\tPOINTS\tTITLE")
+		for _, us := range items {
+			points := "-"
+			if us.Estimate != nil {
+				points = fmt.Sprint(us.Estimate.Points)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", us.ID, us.Status, us.Priority, points, us.Title)
+		}
+	case []*models.DevTask:
+		fmt.Fprintln(tw, "ID\tSTATUS\tTYPE\tASSIGNEE\tESTIMATE\tTITLE")
+		for _, dt := range items {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%v\t%s\n", dt.ID, dt.Status, dt.Type, dt.Assignee, dt.Estimate, dt.Title)
+		}
+	case []*models.Sprint:
+		fmt.Fprintln(tw, "ID\tSTATUS\tSTART\tEND\tGOAL")
+		for _, s := range items {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.ID, s.Status, s.StartDate.Format(query.DateLayout), s.EndDate.Format(query.DateLayout), s.Goal)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// DateLayout is the format of dates in CSV exports
+const DateLayout = "2006-01-02"
+
+// FlowDay is the number of items in each status at the end of a day
+type FlowDay struct {
+	Date   time.Time `json:"date"`
+	Counts []int     `json:"counts"` // One per status of the diagram
+}
+
+// CFD is a cumulative flow diagram: the items in each status day by day
+type CFD struct {
+	SprintID string    `json:"sprint_id,omitempty"`
+	Statuses []string  `json:"statuses"` // Bands in workflow order
+	Days     []FlowDay `json:"days"`
+}
+
+// CumulativeFlow counts the items in each status at the end of every day from start to
+// end. Items in a status not listed and items created later are left out.
+func CumulativeFlow(timelines []Timeline, statuses []string, start, end time.Time) *CFD {
+	index := make(map[string]int, len(statuses))
+	for i, s := range statuses {
+		index[s] = i
+	}
+
+	cfd := &CFD{Statuses: statuses}
+	first := truncateDay(start)
+	last := truncateDay(end)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		counts := make([]int, len(statuses))
+		for _, t := range timelines {
+			status, ok := t.StatusAt(endOfDay)
+			if i, listed := index[status]; ok && listed {
+				counts[i]++
+			}
+		}
+		cfd.Days = append(cfd.Days, FlowDay{Date: day, Counts: counts})
+	}
+	return cfd
+}
+
+// SprintFlow builds the sprint's diagram over the items committed to it, day by day from
+// its start until its end or until the given day, whichever comes first
+func SprintFlow(timelines []Timeline, sprint *models.Sprint, statuses []string, until time.Time) *CFD {
+	end := sprint.EndDate
+	if until.Before(end) {
+		end = until
+	}
+	cfd := CumulativeFlow(InSprint(timelines, sprint), statuses, sprint.StartDate, end)
+	cfd.SprintID = sprint.ID
+	return cfd
+}
+
+// truncateDay returns midnight of the day in the time's location
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// WriteCSV writes one row per day with the date and the count of each status
+func (c *CFD) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(append([]string{"date"}, c.Statuses...)); err != nil {
+		return err
+	}
+	for _, day := range c.Days {
+		row := []string{day.Date.Format(DateLayout)}
+		for _, n := range day.Counts {
+			row = append(row, strconv.Itoa(n))
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteCSVFile writes the diagram into a CSV file at path
+func (c *CFD) WriteCSVFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := c.WriteCSV(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteTimelinesCSV writes one row per item with its start, completion, cycle and lead
+// time in hours, for analysis in a spreadsheet
+func WriteTimelinesCSV(w io.Writer, timelines []Timeline) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"kind", "id", "type", "story_id", "created", "started", "completed", "cycle_hours", "lead_hours"}); err != nil {
+		return err
+	}
+	for _, t := range timelines {
+		row := []string{string(t.Kind), t.ID, t.Type, t.StoryID, t.Created().Format(time.RFC3339), "", "", "", ""}
+		if started, ok := t.Started(); ok {
+			row[5] = started.Format(time.RFC3339)
+		}
+		if completed, ok := t.Completed(); ok {
+			row[6] = completed.Format(time.RFC3339)
+		}
+		if d, ok := t.CycleTime(); ok {
+			row[7] = strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+		}
+		if d, ok := t.LeadTime(); ok {
+			row[8] = strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,61 @@
+package analytics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+func TestSprintFlow(t *testing.T) {
+	events := flowEvents(t)
+	stories := Timelines(events, models.KindStory)
+	sprint := &models.Sprint{ID: "sprint-1", StartDate: day0, EndDate: day0.AddDate(0, 0, 9), Committed: []string{"a", "b"}}
+
+	cfd := SprintFlow(stories, sprint, StoryStatuses, day0.AddDate(0, 0, 3))
+
+	// Check one day per sprint day until the cut-off
+	if cfd.SprintID != "sprint-1" || len(cfd.Days) != 4 {
+		t.Fatalf("Expected 4 days for sprint-1, got %+v", cfd)
+	}
+
+	// Check the end-of-day counts as Draft, Ready, InProgress, Done
+	want := [][]int{{1, 1, 0, 0}, {0, 1, 1, 0}, {0, 0, 2, 0}, {0, 0, 1, 1}}
+	for i, day := range cfd.Days {
+		for j := range want[i] {
+			if day.Counts[j] != want[i][j] {
+				t.Errorf("Expected %v on day %d, got %v", want[i], i, day.Counts)
+				break
+			}
+		}
+	}
+
+	// Check the CSV export
+	path := filepath.Join(t.TempDir(), "cfd.csv")
+	if err := cfd.WriteCSVFile(path); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 5 || lines[0] != "date,Draft,Ready,InProgress,Done" || lines[4] != "2025-09-25,0,0,1,1" {
+		t.Errorf("Expected header and 4 rows ending 2025-09-25,0,0,1,1, got %q", lines)
+	}
+}
+
+func TestWriteTimelinesCSV(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTimelinesCSV(&b, Timelines(flowEvents(t), models.KindTask)); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	// Check that t1 reports a 24 hour cycle and 48 hour lead time
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "task,t1,Development,a,") || !strings.HasSuffix(lines[1], ",24.00,48.00") {
+		t.Errorf("Expected a row for t1 with 24 and 48 hours, got %q", lines)
+	}
+}
@@ -0,0 +1,132 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Stats summarizes a set of durations
+type Stats struct {
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean"`
+	Min   time.Duration `json:"min"`
+	P50   time.Duration `json:"p50"`
+	P85   time.Duration `json:"p85"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+// Summarize computes the mean and nearest-rank percentiles of the durations
+func Summarize(durations []time.Duration) Stats {
+	if len(durations) == 0 {
+		return Stats{}
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return Stats{
+		Count: len(sorted),
+		Mean:  total / time.Duration(len(sorted)),
+		Min:   sorted[0],
+		P50:   Percentile(sorted, 0.5),
+		P85:   Percentile(sorted, 0.85),
+		P95:   Percentile(sorted, 0.95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// Percentile returns the smallest of the sorted durations at or above the given fraction
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// CycleTime summarizes the cycle time of the finished items
+func CycleTime(timelines []Timeline) Stats {
+	return summarizeBy(timelines, Timeline.CycleTime)
+}
+
+// LeadTime summarizes the lead time of the finished items
+func LeadTime(timelines []Timeline) Stats {
+	return summarizeBy(timelines, Timeline.LeadTime)
+}
+
+// summarizeBy summarizes the durations the metric yields
+func summarizeBy(timelines []Timeline, metric func(Timeline) (time.Duration, bool)) Stats {
+	var durations []time.Duration
+	for _, t := range timelines {
+		if d, ok := metric(t); ok {
+			durations = append(durations, d)
+		}
+	}
+	return Summarize(durations)
+}
+
+// TypeStats is the flow of one task type, or of stories under ""
+type TypeStats struct {
+	Type      string `json:"type"`
+	CycleTime Stats  `json:"cycle_time"`
+	LeadTime  Stats  `json:"lead_time"`
+}
+
+// ByType summarizes cycle and lead time per task type, sorted by type
+func ByType(timelines []Timeline) []TypeStats {
+	groups := GroupByType(timelines)
+	types := make([]string, 0, len(groups))
+	for t := range groups {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	stats := make([]TypeStats, 0, len(types))
+	for _, t := range types {
+		stats = append(stats, TypeStats{Type: t, CycleTime: CycleTime(groups[t]), LeadTime: LeadTime(groups[t])})
+	}
+	return stats
+}
+
+// Period is the number of items finished in a time window
+type Period struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Count int       `json:"count"`
+}
+
+// Throughput counts the items finished in consecutive windows of the given length from
+// start until end. The last window may be shorter.
+func Throughput(timelines []Timeline, start, end time.Time, window time.Duration) []Period {
+	if window <= 0 || !end.After(start) {
+		return nil
+	}
+
+	var periods []Period
+	for from := start; from.Before(end); from = from.Add(window) {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+		periods = append(periods, Period{Start: from, End: to})
+	}
+	for _, t := range timelines {
+		completed, ok := t.Completed()
+		if !ok || completed.Before(start) || !completed.Before(end) {
+			continue
+		}
+		periods[int(completed.Sub(start)/window)].Count++
+	}
+	return periods
+}
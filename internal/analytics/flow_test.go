@@ -0,0 +1,60 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+func TestSummarize(t *testing.T) {
+	var durations []time.Duration
+	for i := 10; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Hour)
+	}
+	stats := Summarize(durations)
+
+	// Check the nearest-rank percentiles over 1h..10h
+	if stats.Count != 10 || stats.Min != time.Hour || stats.Max != 10*time.Hour {
+		t.Errorf("Expected 10 durations from 1h to 10h, got %+v", stats)
+	}
+	if stats.P50 != 5*time.Hour || stats.P85 != 9*time.Hour || stats.P95 != 10*time.Hour || stats.Mean != 5*time.Hour+30*time.Minute {
+		t.Errorf("Expected p50 5h, p85 9h, p95 10h and mean 5h30m, got %+v", stats)
+	}
+
+	// Check that no durations give zero stats
+	if empty := Summarize(nil); empty.Count != 0 || empty.P85 != 0 {
+		t.Errorf("Expected zero stats, got %+v", empty)
+	}
+}
+
+func TestByTypeAndThroughput(t *testing.T) {
+	events := flowEvents(t)
+	tasks := Timelines(events, models.KindTask)
+
+	// Check the per-type breakdown: t1 took a day, t2 a day including its block
+	stats := ByType(tasks)
+	if len(stats) != 2 || stats[0].Type != "Development" || stats[1].Type != "Testing" {
+		t.Fatalf("Expected Development and Testing, got %+v", stats)
+	}
+	if stats[0].CycleTime.P50 != 24*time.Hour || stats[1].LeadTime.P50 != 48*time.Hour {
+		t.Errorf("Expected 1 day Development cycle and 2 day Testing lead time, got %+v", stats)
+	}
+
+	// Check that the overall cycle time covers both tasks
+	if all := CycleTime(tasks); all.Count != 2 || all.Mean != 24*time.Hour {
+		t.Errorf("Expected 2 tasks averaging 1 day, got %+v", all)
+	}
+
+	// Check daily throughput over the first four days
+	periods := Throughput(tasks, day0, day0.AddDate(0, 0, 4), 24*time.Hour)
+	counts := []int{0, 0, 1, 1}
+	if len(periods) != 4 {
+		t.Fatalf("Expected 4 periods, got %d", len(periods))
+	}
+	for i, p := range periods {
+		if p.Count != counts[i] {
+			t.Errorf("Expected %d finished on day %d, got %d", counts[i], i, p.Count)
+		}
+	}
+}
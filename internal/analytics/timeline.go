@@ -0,0 +1,180 @@
+// Package analytics computes flow metrics for the Scrum Master: cycle time, lead time,
+// throughput and cumulative flow, from the status history in the event log.
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+// Statuses shared by stories and tasks that mark the start and end of work
+const (
+	StatusInProgress = "InProgress"
+	StatusDone       = "Done"
+)
+
+// StoryStatuses are the story statuses in workflow order, as CFD bands
+var StoryStatuses = []string{
+	string(models.StoryDraft),
+	string(models.StoryReady),
+	string(models.StoryInProgress),
+	string(models.StoryDone),
+}
+
+// TaskStatuses are the task statuses in workflow order, as CFD bands
+var TaskStatuses = []string{
+	string(models.TaskTodo),
+	string(models.TaskBlocked),
+	string(models.TaskInProgress),
+	string(models.TaskDone),
+}
+
+// StatusChange is a status an item entered and when
+type StatusChange struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// Timeline is the status history of one story or task
+type Timeline struct {
+	Kind     models.EntityKind `json:"kind"`
+	ID       string            `json:"id"`
+	Type     string            `json:"type,omitempty"`     // Task type, empty for stories
+	StoryID  string            `json:"story_id,omitempty"` // Story of a task
+	Statuses []StatusChange    `json:"statuses"`           // Starts with the status at creation
+}
+
+// Created returns when the item was created
+func (t Timeline) Created() time.Time {
+	if len(t.Statuses) == 0 {
+		return time.Time{}
+	}
+	return t.Statuses[0].At
+}
+
+// StatusAt returns the item's status at the given time, false before it was created
+func (t Timeline) StatusAt(at time.Time) (string, bool) {
+	status, ok := "", false
+	for _, c := range t.Statuses {
+		if c.At.After(at) {
+			break
+		}
+		status, ok = c.Status, true
+	}
+	return status, ok
+}
+
+// Started returns when work on the item first started
+func (t Timeline) Started() (time.Time, bool) {
+	for _, c := range t.Statuses {
+		if c.Status == StatusInProgress {
+			return c.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Completed returns when the item was last finished, if it is Done now
+func (t Timeline) Completed() (time.Time, bool) {
+	if len(t.Statuses) == 0 || t.Statuses[len(t.Statuses)-1].Status != StatusDone {
+		return time.Time{}, false
+	}
+	return t.Statuses[len(t.Statuses)-1].At, true
+}
+
+// CycleTime returns the time from the first start to completion
+func (t Timeline) CycleTime() (time.Duration, bool) {
+	started, ok := t.Started()
+	completed, done := t.Completed()
+	if !ok || !done {
+		return 0, false
+	}
+	return completed.Sub(started), true
+}
+
+// LeadTime returns the time from creation to completion
+func (t Timeline) LeadTime() (time.Duration, bool) {
+	completed, ok := t.Completed()
+	if !ok {
+		return 0, false
+	}
+	return completed.Sub(t.Created()), true
+}
+
+// Timelines rebuilds the status history of every story or task in the events, skipping
+// undone ones, ordered by creation
+func Timelines(events []models.Event, kind models.EntityKind) []Timeline {
+	snapshot := store.Project(events)
+
+	var timelines []Timeline
+	for id, history := range store.Histories(events, kind) {
+		t := Timeline{Kind: kind, ID: id}
+		var current string
+		switch kind {
+		case models.KindStory:
+			us, ok := snapshot.Stories[id]
+			if !ok {
+				continue
+			}
+			current = string(us.Status)
+		case models.KindTask:
+			dt, ok := snapshot.Tasks[id]
+			if !ok {
+				continue
+			}
+			current = string(dt.Status)
+			t.Type = string(dt.Type)
+			t.StoryID = dt.StoryID
+		default:
+			continue
+		}
+
+		// The status at creation is the old value of the first status change
+		statuses := history.Field("status")
+		initial := current
+		if len(statuses) > 0 {
+			initial = statuses[0].Old
+		}
+		t.Statuses = append(t.Statuses, StatusChange{Status: initial, At: history[0].At})
+		for _, c := range statuses {
+			t.Statuses = append(t.Statuses, StatusChange{Status: c.New, At: c.At})
+		}
+		timelines = append(timelines, t)
+	}
+
+	sort.Slice(timelines, func(i, j int) bool {
+		if !timelines[i].Created().Equal(timelines[j].Created()) {
+			return timelines[i].Created().Before(timelines[j].Created())
+		}
+		return timelines[i].ID < timelines[j].ID
+	})
+	return timelines
+}
+
+// InSprint keeps the stories committed to the sprint and the tasks of those stories
+func InSprint(timelines []Timeline, sprint *models.Sprint) []Timeline {
+	committed := make(map[string]bool, len(sprint.Committed))
+	for _, id := range sprint.Committed {
+		committed[id] = true
+	}
+
+	var kept []Timeline
+	for _, t := range timelines {
+		if (t.Kind == models.KindStory && committed[t.ID]) || (t.Kind == models.KindTask && committed[t.StoryID]) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// GroupByType splits the timelines by task type; stories are grouped under ""
+func GroupByType(timelines []Timeline) map[string][]Timeline {
+	groups := make(map[string][]Timeline)
+	for _, t := range timelines {
+		groups[t.Type] = append(groups[t.Type], t)
+	}
+	return groups
+}
@@ -0,0 +1,122 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+// day0 is the first day of the test sprint
+var day0 = time.Date(2025, 9, 22, 9, 0, 0, 0, time.UTC)
+
+// clockRecorder records events into the store at a settable time
+type clockRecorder struct {
+	store store.EventStore
+	now   time.Time
+}
+
+func (c *clockRecorder) Record(e models.Event) {
+	e.Timestamp = c.now
+	c.store.Record(e)
+}
+
+// at moves the clock to the given day and hour offset from day0
+func (c *clockRecorder) at(day int, hours time.Duration) {
+	c.now = day0.AddDate(0, 0, day).Add(hours)
+}
+
+// flowEvents records two stories and two tasks moving through the workflow:
+// story a is Done on day 3, story b is InProgress since day 2, task t1 (Development)
+// is Done on day 2 and task t2 (Testing) is Done on day 3 after a short block
+func flowEvents(t *testing.T) []models.Event {
+	c := &clockRecorder{store: store.New()}
+	ready := func(id string) *models.UserStory {
+		us := models.NewUserStory("Story "+id, "")
+		us.ID = id
+		us.AddAcceptanceCriterion("It works")
+		us.SetEstimate(3)
+		us.Track(c)
+		return us
+	}
+	must := func(err error) {
+		if err != nil {
+			t.Fatalf("Failed to change status: %v", err)
+		}
+	}
+
+	c.at(0, 0)
+	a := ready("a")
+	b := ready("b")
+	t1 := models.NewDevTask("a", "Build", "dev-1")
+	t1.ID = "t1"
+	t1.Track(c)
+	must(a.SetStatus(models.StoryReady))
+
+	c.at(1, 0)
+	must(a.SetStatus(models.StoryInProgress))
+	must(b.SetStatus(models.StoryReady))
+	must(t1.SetStatus(models.TaskInProgress))
+	t2 := models.NewDevTask("a", "Check", "dev-2")
+	t2.ID = "t2"
+	t2.Update(t2.Title, models.TaskTesting, t2.Estimate, t2.Assignee)
+	t2.Track(c)
+
+	c.at(2, 0)
+	must(b.SetStatus(models.StoryInProgress))
+	must(t1.SetStatus(models.TaskDone))
+	must(t2.SetStatus(models.TaskInProgress))
+	c.at(2, time.Hour)
+	must(t2.SetStatus(models.TaskBlocked))
+	c.at(2, 2*time.Hour)
+	must(t2.SetStatus(models.TaskInProgress))
+
+	c.at(3, 0)
+	must(t2.SetStatus(models.TaskDone))
+	must(a.SetStatus(models.StoryDone))
+
+	return c.store.Events()
+}
+
+func TestTimelines(t *testing.T) {
+	events := flowEvents(t)
+	stories := Timelines(events, models.KindStory)
+
+	// Check the stories in creation order with their status history
+	if len(stories) != 2 || stories[0].ID != "a" || len(stories[0].Statuses) != 4 {
+		t.Fatalf("Expected stories a and b with a's 4 statuses, got %+v", stories)
+	}
+	if stories[0].Statuses[0].Status != "Draft" || !stories[0].Created().Equal(day0) {
+		t.Errorf("Expected a to be created Draft on day 0, got %+v", stories[0].Statuses[0])
+	}
+	if cycle, ok := stories[0].CycleTime(); !ok || cycle != 48*time.Hour {
+		t.Errorf("Expected a cycle time of 2 days, got %v", cycle)
+	}
+	if lead, ok := stories[0].LeadTime(); !ok || lead != 72*time.Hour {
+		t.Errorf("Expected a lead time of 3 days, got %v", lead)
+	}
+	if _, ok := stories[1].CycleTime(); ok {
+		t.Errorf("Expected no cycle time for unfinished story b")
+	}
+
+	// Check the status at a given time
+	if status, ok := stories[1].StatusAt(day0.AddDate(0, 0, 1).Add(time.Hour)); !ok || status != "Ready" {
+		t.Errorf("Expected b to be Ready on day 1, got %q", status)
+	}
+	if _, ok := stories[1].StatusAt(day0.Add(-time.Hour)); ok {
+		t.Errorf("Expected no status before creation")
+	}
+
+	// Check the task types and stories
+	tasks := Timelines(events, models.KindTask)
+	if len(tasks) != 2 || tasks[1].Type != "Testing" || tasks[1].StoryID != "a" {
+		t.Errorf("Expected task t2 of type Testing on story a, got %+v", tasks)
+	}
+
+	// Check that a sprint keeps only its committed stories and their tasks
+	sprint := &models.Sprint{ID: "sprint-1", Committed: []string{"b"}}
+	if kept := InSprint(append(stories, tasks...), sprint); len(kept) != 1 || kept[0].ID != "b" {
+		t.Errorf("Expected only story b, got %+v", kept)
+	}
+}
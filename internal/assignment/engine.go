@@ -0,0 +1,165 @@
+// Package assignment picks an assignee for each development task
+// in the agile team intelligent agent system.
+package assignment
+
+import (
+	"fmt"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// assignmentError implements the error interface
+type assignmentError string
+
+func (e assignmentError) Error() string {
+	return string(e)
+}
+
+// ErrNoCandidates is returned when the team has no member who can take tasks
+var ErrNoCandidates = assignmentError("no team member can take tasks")
+
+// Candidate is a team member being considered for a task, with their current load
+type Candidate struct {
+	Member    *models.Member
+	WIP       int           // Tasks in progress
+	Load      time.Duration // Estimates of assigned, unfinished tasks
+	Available time.Duration // Focused capacity over the sprint
+}
+
+// Remaining returns the capacity left after the current load
+func (c Candidate) Remaining() time.Duration {
+	return c.Available - c.Load
+}
+
+// Strategy chooses one of the candidates for a task and explains why
+type Strategy interface {
+	// Name identifies the strategy in decisions
+	Name() string
+
+	// Choose returns the index of the chosen candidate and the reason for the choice
+	Choose(task *models.DevTask, candidates []Candidate) (int, string)
+}
+
+// Decision records an assignment and its explanation
+type Decision struct {
+	TaskID   string `json:"task_id"`
+	MemberID string `json:"member_id"`
+	Strategy string `json:"strategy"`
+	Reason   string `json:"reason"`
+}
+
+// Engine assigns tasks to team members for a sprint
+type Engine struct {
+	team       *models.Team
+	sprint     *models.Sprint
+	strategy   Strategy
+	candidates []Candidate
+}
+
+// NewEngine creates an engine using the given strategy over the developers and testers of the team
+func NewEngine(team *models.Team, sprint *models.Sprint, strategy Strategy) *Engine {
+	e := &Engine{team: team, sprint: sprint, strategy: strategy}
+	for _, m := range team.Members {
+		if m.Role != models.RoleDeveloper && m.Role != models.RoleTester {
+			continue
+		}
+		e.candidates = append(e.candidates, Candidate{Member: m, Available: team.MemberCapacity(m, sprint)})
+	}
+	return e
+}
+
+// Assign gives every unassigned task an assignee, visiting tasks in dependency order.
+// Tasks already assigned count towards their assignee's WIP and load. Dependencies on
+// tasks outside the given ones, such as those of other stories, do not affect the order.
+func (e *Engine) Assign(tasks []*models.DevTask) ([]Decision, error) {
+	if len(e.candidates) == 0 {
+		return nil, ErrNoCandidates
+	}
+
+	ordered, err := inOrder(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if task.Assignee != "" {
+			e.track(task.Assignee, task)
+		}
+	}
+
+	var decisions []Decision
+	for _, task := range ordered {
+		if task.Assignee != "" || task.Status == models.TaskDone {
+			continue
+		}
+
+		i, reason := e.strategy.Choose(task, e.candidates)
+		chosen := e.candidates[i]
+		if task.Estimate > chosen.Remaining() {
+			reason += fmt.Sprintf("; exceeds remaining capacity by %v", task.Estimate-chosen.Remaining())
+		}
+
+		task.Update(task.Title, task.Type, task.Estimate, chosen.Member.ID)
+		e.track(chosen.Member.ID, task)
+		decisions = append(decisions, Decision{
+			TaskID:   task.ID,
+			MemberID: chosen.Member.ID,
+			Strategy: e.strategy.Name(),
+			Reason:   reason,
+		})
+	}
+
+	return decisions, nil
+}
+
+// inOrder returns the tasks in dependency order, ignoring dependencies on tasks outside them
+func inOrder(tasks []*models.DevTask) ([]*models.DevTask, error) {
+	byID := make(map[string]*models.DevTask, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	// Order copies limited to the dependencies within the set, leaving the tasks untouched
+	views := make([]*models.DevTask, 0, len(tasks))
+	for _, task := range tasks {
+		view := *task
+		view.Dependencies = nil
+		for _, dep := range task.Dependencies {
+			if _, ok := byID[dep]; ok {
+				view.Dependencies = append(view.Dependencies, dep)
+			}
+		}
+		views = append(views, &view)
+	}
+	graph, err := models.NewTaskGraph(views...)
+	if err != nil {
+		return nil, err
+	}
+	ordered, err := graph.TopologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*models.DevTask, len(ordered))
+	for i, view := range ordered {
+		out[i] = byID[view.ID]
+	}
+	return out, nil
+}
+
+// track adds an assigned task to its assignee's WIP and load
+func (e *Engine) track(memberID string, task *models.DevTask) {
+	for i := range e.candidates {
+		if e.candidates[i].Member.ID != memberID {
+			continue
+		}
+		if task.Status == models.TaskInProgress {
+			e.candidates[i].WIP++
+		}
+		if task.Status != models.TaskDone {
+			e.candidates[i].Load += task.Estimate
+		}
+		return
+	}
+}
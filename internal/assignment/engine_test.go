@@ -0,0 +1,154 @@
+package assignment
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// newTestTeam returns a team with a developer, a tester and a scrum master
+func newTestTeam() (*models.Team, *models.Member, *models.Member) {
+	team := models.NewTeam("Core")
+	dev := models.NewMember("Alice", models.RoleDeveloper)
+	dev.AddSkill(string(models.TaskDevelopment))
+	tester := models.NewMember("Bob", models.RoleTester)
+	tester.AddSkill(string(models.TaskTesting))
+	team.AddMember(dev)
+	team.AddMember(tester)
+	team.AddMember(models.NewMember("Carol", models.RoleScrumMaster))
+	return team, dev, tester
+}
+
+// newTestSprint returns a one-week sprint
+func newTestSprint() *models.Sprint {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	return models.NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+}
+
+func TestAssignSkipsNonDevelopers(t *testing.T) {
+	team := models.NewTeam("Core")
+	team.AddMember(models.NewMember("Carol", models.RoleScrumMaster))
+
+	e := NewEngine(team, newTestSprint(), SkillMatch{})
+	if _, err := e.Assign([]*models.DevTask{models.NewDevTask("story-1", "API", "")}); err != ErrNoCandidates {
+		t.Errorf("Expected error %v, got %v", ErrNoCandidates, err)
+	}
+}
+
+func TestAssignSkillMatch(t *testing.T) {
+	team, dev, tester := newTestTeam()
+	build := models.NewDevTask("story-1", "Implement login API", "")
+	test := models.NewDevTask("story-1", "Test login API", "")
+	test.Update(test.Title, models.TaskTesting, 2*time.Hour, "")
+	test.AddDependency(build.ID)
+	deploy := models.NewDevTask("story-1", "Deploy login API", "")
+	deploy.Update(deploy.Title, models.TaskDeployment, time.Hour, "")
+
+	// Tasks are listed out of dependency order on purpose
+	decisions, err := NewEngine(team, newTestSprint(), SkillMatch{}).Assign([]*models.DevTask{test, build, deploy})
+	if err != nil {
+		t.Fatalf("Failed to assign tasks: %v", err)
+	}
+
+	// Check that the build task was decided before the test task depending on it
+	if len(decisions) != 3 {
+		t.Fatalf("Expected 3 decisions, got %+v", decisions)
+	}
+	byTask := make(map[string]int)
+	for i, d := range decisions {
+		byTask[d.TaskID] = i
+	}
+	if byTask[build.ID] > byTask[test.ID] {
+		t.Errorf("Expected build to be assigned before test, got %+v", decisions)
+	}
+	if build.Assignee != dev.ID || test.Assignee != tester.ID {
+		t.Errorf("Expected development to Alice and testing to Bob, got %s and %s", build.Assignee, test.Assignee)
+	}
+
+	// Without a matching skill the engine falls back to load balancing and says so
+	d := decisions[byTask[deploy.ID]]
+	if !strings.Contains(d.Reason, "nobody has the Deployment skill") {
+		t.Errorf("Expected fallback explanation, got %q", d.Reason)
+	}
+	if d.MemberID != tester.ID {
+		t.Errorf("Expected deployment to go to the less loaded Bob, got %s", d.MemberID)
+	}
+}
+
+func TestAssignRoundRobin(t *testing.T) {
+	team, dev, tester := newTestTeam()
+	tasks := []*models.DevTask{
+		models.NewDevTask("story-1", "Task 1", ""),
+		models.NewDevTask("story-1", "Task 2", ""),
+		models.NewDevTask("story-1", "Task 3", ""),
+	}
+
+	decisions, err := NewEngine(team, newTestSprint(), &RoundRobin{}).Assign(tasks)
+	if err != nil {
+		t.Fatalf("Failed to assign tasks: %v", err)
+	}
+
+	// Check that members take turns
+	expected := []string{dev.ID, tester.ID, dev.ID}
+	for i, d := range decisions {
+		if d.MemberID != expected[i] || d.Strategy != "round-robin" {
+			t.Errorf("Expected task %d to go to %s by round-robin, got %+v", i, expected[i], d)
+		}
+	}
+}
+
+func TestAssignLoadBalanceCountsExistingWork(t *testing.T) {
+	team, dev, tester := newTestTeam()
+
+	// Alice already carries a big task
+	existing := models.NewDevTask("story-1", "Existing work", dev.ID)
+	existing.Update(existing.Title, existing.Type, 20*time.Hour, dev.ID)
+	task := models.NewDevTask("story-1", "New work", "")
+
+	decisions, err := NewEngine(team, newTestSprint(), LoadBalance{}).Assign([]*models.DevTask{existing, task})
+	if err != nil {
+		t.Fatalf("Failed to assign tasks: %v", err)
+	}
+	if len(decisions) != 1 || decisions[0].MemberID != tester.ID {
+		t.Errorf("Expected the new task to go to Bob, got %+v", decisions)
+	}
+}
+
+func TestAssignExplainsOverCapacity(t *testing.T) {
+	team, _, _ := newTestTeam()
+	big := models.NewDevTask("story-1", "Rewrite everything", "")
+	big.Update(big.Title, big.Type, 100*time.Hour, "")
+
+	decisions, err := NewEngine(team, newTestSprint(), SkillMatch{}).Assign([]*models.DevTask{big})
+	if err != nil {
+		t.Fatalf("Failed to assign tasks: %v", err)
+	}
+	if !strings.Contains(decisions[0].Reason, "exceeds remaining capacity") {
+		t.Errorf("Expected capacity warning in the explanation, got %q", decisions[0].Reason)
+	}
+}
+
+func TestAssignOutsideDependencies(t *testing.T) {
+	team, dev, _ := newTestTeam()
+	build := models.NewDevTask("story-1", "Implement login API", "")
+	test := models.NewDevTask("story-1", "Test login API", "")
+	test.AddDependency(build.ID)
+	test.AddDependency("task-of-another-story")
+
+	decisions, err := NewEngine(team, newTestSprint(), SkillMatch{}).Assign([]*models.DevTask{test, build})
+
+	// Check that a dependency outside the tasks does not fail the assignment
+	if err != nil {
+		t.Fatalf("Failed to assign tasks: %v", err)
+	}
+	if len(decisions) != 2 || decisions[0].TaskID != build.ID || decisions[1].TaskID != test.ID {
+		t.Errorf("Expected build then test to be assigned, got %+v", decisions)
+	}
+
+	// Check that the task keeps its dependencies
+	if len(test.Dependencies) != 2 || test.Assignee != dev.ID {
+		t.Errorf("Expected both dependencies kept and the task assigned to %s, got %+v", dev.ID, test)
+	}
+}
@@ -0,0 +1,84 @@
+package assignment
+
+import (
+	"fmt"
+
+	"egodteam/internal/data/models"
+)
+
+// RoundRobin assigns tasks to candidates in turn
+type RoundRobin struct {
+	next int
+}
+
+// Name identifies the strategy in decisions
+func (r *RoundRobin) Name() string {
+	return "round-robin"
+}
+
+// Choose returns the next candidate in turn
+func (r *RoundRobin) Choose(task *models.DevTask, candidates []Candidate) (int, string) {
+	i := r.next % len(candidates)
+	r.next++
+	return i, fmt.Sprintf("%s is next in turn", candidates[i].Member.Name)
+}
+
+// SkillMatch prefers candidates whose skills include the task type,
+// then the one with the most remaining capacity
+type SkillMatch struct{}
+
+// Name identifies the strategy in decisions
+func (SkillMatch) Name() string {
+	return "skill-match"
+}
+
+// Choose returns the skilled candidate with the most remaining capacity
+func (SkillMatch) Choose(task *models.DevTask, candidates []Candidate) (int, string) {
+	best := -1
+	for i, c := range candidates {
+		if !c.Member.HasSkill(string(task.Type)) {
+			continue
+		}
+		if best < 0 || c.Remaining() > candidates[best].Remaining() {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return best, fmt.Sprintf("%s has the %s skill and %v remaining capacity",
+			candidates[best].Member.Name, task.Type, candidates[best].Remaining())
+	}
+
+	// Nobody has the skill, fall back to balancing the load
+	i, reason := LoadBalance{}.Choose(task, candidates)
+	return i, fmt.Sprintf("nobody has the %s skill; %s", task.Type, reason)
+}
+
+// LoadBalance prefers the candidate with the lowest share of capacity in use, then the lowest WIP
+type LoadBalance struct{}
+
+// Name identifies the strategy in decisions
+func (LoadBalance) Name() string {
+	return "load-balance"
+}
+
+// Choose returns the least loaded candidate
+func (LoadBalance) Choose(task *models.DevTask, candidates []Candidate) (int, string) {
+	best := 0
+	for i, c := range candidates[1:] {
+		if utilization(c) < utilization(candidates[best]) ||
+			(utilization(c) == utilization(candidates[best]) && c.WIP < candidates[best].WIP) {
+			best = i + 1
+		}
+	}
+	c := candidates[best]
+	return best, fmt.Sprintf("%s has the lowest load (%.0f%% of capacity, %d in progress)",
+		c.Member.Name, 100*utilization(c), c.WIP)
+}
+
+// utilization returns the share of capacity already assigned; members without capacity count as full
+func utilization(c Candidate) float64 {
+	if c.Available <= 0 {
+		return 1
+	}
+	return float64(c.Load) / float64(c.Available)
+}
@@ -0,0 +1,33 @@
+package assignment
+
+import (
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+func TestLoadBalanceTieBreaksOnWIP(t *testing.T) {
+	alice := models.NewMember("Alice", models.RoleDeveloper)
+	bob := models.NewMember("Bob", models.RoleDeveloper)
+	candidates := []Candidate{
+		{Member: alice, WIP: 2, Available: 10 * time.Hour},
+		{Member: bob, WIP: 1, Available: 10 * time.Hour},
+	}
+
+	// Equal utilization falls back to the lower WIP
+	i, _ := LoadBalance{}.Choose(models.NewDevTask("story-1", "Task", ""), candidates)
+	if i != 1 {
+		t.Errorf("Expected Bob to be chosen, got %s", candidates[i].Member.Name)
+	}
+}
+
+func TestUtilizationWithoutCapacity(t *testing.T) {
+	// Members without capacity count as fully loaded
+	if u := utilization(Candidate{}); u != 1 {
+		t.Errorf("Expected utilization 1, got %v", u)
+	}
+	if u := utilization(Candidate{Load: time.Hour, Available: 4 * time.Hour}); u != 0.25 {
+		t.Errorf("Expected utilization 0.25, got %v", u)
+	}
+}
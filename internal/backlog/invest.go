@@ -0,0 +1,204 @@
+package backlog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"egodteam/internal/data/models"
+)
+
+// INVEST rules beyond the acceptance criteria checks
+const (
+	RuleTemplate        Rule = "template"
+	RuleValueMissing    Rule = "value-missing"
+	RuleUnestimated     Rule = "unestimated"
+	RuleTooLarge        Rule = "too-large"
+	RuleDependency      Rule = "dependency"
+	RuleDependencyCycle Rule = "dependency-cycle"
+)
+
+// penalties are the points a finding of each severity costs a story's score
+var penalties = map[Severity]int{
+	SeverityError:   30,
+	SeverityWarning: 15,
+	SeverityInfo:    5,
+}
+
+// englishTemplate matches "As a [role], I want [feature], so that [value]"
+var englishTemplate = regexp.MustCompile(`(?is)^\s*as an?\s+(.+?),?\s+i\s+(?:want|would like|need)\s+(?:to\s+)?(.+?)(?:,?\s+so\s+that\s+(.+?))?[.。]?\s*$`)
+
+// chineseTemplate matches "作为[角色]，我想要[功能]，以便[价值]"
+var chineseTemplate = regexp.MustCompile(`(?s)^\s*作为(.+?)[，,]\s*我(?:想要|希望|需要|想)(.+?)(?:[，,]\s*(?:以便|从而|为了)(.+?))?[.。]?\s*$`)
+
+// Template is the parsed "As a [role], I want [feature], so that [value]" description
+type Template struct {
+	Role    string `json:"role"`
+	Feature string `json:"feature"`
+	Value   string `json:"value,omitempty"`
+}
+
+// ParseTemplate parses a story description in the English or Chinese story template
+func ParseTemplate(description string) (Template, bool) {
+	for _, pattern := range []*regexp.Regexp{englishTemplate, chineseTemplate} {
+		if m := pattern.FindStringSubmatch(description); m != nil {
+			return Template{
+				Role:    strings.TrimSpace(m[1]),
+				Feature: strings.TrimSpace(m[2]),
+				Value:   strings.TrimSpace(m[3]),
+			}, true
+		}
+	}
+	return Template{}, false
+}
+
+// Assessment is the INVEST verdict on one story
+type Assessment struct {
+	StoryID  string    `json:"story_id"`
+	Score    int       `json:"score"` // 100 minus the penalties of the findings, at least 0
+	Ready    bool      `json:"ready"` // No error findings
+	Findings []Warning `json:"findings"`
+}
+
+// Checker runs the INVEST checks over the backlog
+type Checker struct {
+	Velocity   float64 // Average points per sprint, 0 if unknown
+	SmallShare float64 // Largest share of the velocity one story may take
+	MaxPoints  int     // Largest story when the velocity is unknown
+}
+
+// NewChecker creates a checker allowing stories up to half the velocity, or 13 points without one
+func NewChecker(velocity float64) *Checker {
+	return &Checker{Velocity: velocity, SmallShare: 0.5, MaxPoints: 13}
+}
+
+// Check assesses every story that is not Done. Tasks give the dependencies between stories.
+func (c *Checker) Check(stories []*models.UserStory, tasks []*models.DevTask) []Assessment {
+	status := make(map[string]models.StoryStatus, len(stories))
+	for _, us := range stories {
+		status[us.ID] = us.Status
+	}
+	deps := models.StoryDependencies(tasks)
+	cyclic := cyclicStories(deps)
+
+	var assessments []Assessment
+	for _, us := range stories {
+		if us.Status == models.StoryDone {
+			continue
+		}
+
+		var findings []Warning
+		findings = append(findings, c.checkTemplate(us)...)
+		findings = append(findings, c.checkSize(us)...)
+		findings = append(findings, LintCriteria(us)...)
+
+		if cyclic[us.ID] {
+			findings = append(findings, Warning{
+				StoryID: us.ID, Rule: RuleDependencyCycle, Principle: Independent, Severity: SeverityError,
+				Message: "story is part of a dependency cycle; merge or re-slice the stories involved",
+			})
+		}
+		for _, dep := range deps[us.ID] {
+			if status[dep] == models.StoryDone {
+				continue
+			}
+			findings = append(findings, Warning{
+				StoryID: us.ID, Rule: RuleDependency, Principle: Independent, Severity: SeverityInfo,
+				Message: fmt.Sprintf("depends on unfinished story %s", dep),
+			})
+		}
+
+		assessments = append(assessments, assess(us.ID, findings))
+	}
+	return assessments
+}
+
+// assess scores the findings of a story
+func assess(storyID string, findings []Warning) Assessment {
+	a := Assessment{StoryID: storyID, Score: 100, Ready: true, Findings: findings}
+	for _, f := range findings {
+		a.Score -= penalties[f.Severity]
+		if f.Severity == SeverityError {
+			a.Ready = false
+		}
+	}
+	if a.Score < 0 {
+		a.Score = 0
+	}
+	if a.Findings == nil {
+		a.Findings = []Warning{}
+	}
+	return a
+}
+
+// checkTemplate checks the description against the story template
+func (c *Checker) checkTemplate(us *models.UserStory) []Warning {
+	t, ok := ParseTemplate(us.Description)
+	if !ok {
+		return []Warning{{
+			StoryID: us.ID, Rule: RuleTemplate, Principle: Valuable, Severity: SeverityWarning,
+			Message: `description does not follow "As a [role], I want [feature], so that [value]" or "作为[角色]，我想要[功能]，以便[价值]"`,
+		}}
+	}
+	if t.Value == "" {
+		return []Warning{{
+			StoryID: us.ID, Rule: RuleValueMissing, Principle: Valuable, Severity: SeverityWarning,
+			Message: `description does not say why: add "so that [value]" or "以便[价值]"`,
+		}}
+	}
+	return nil
+}
+
+// checkSize checks that the story is estimated and fits comfortably in a sprint
+func (c *Checker) checkSize(us *models.UserStory) []Warning {
+	if us.Estimate == nil || us.Estimate.Points <= 0 {
+		return []Warning{{
+			StoryID: us.ID, Rule: RuleUnestimated, Principle: Estimable, Severity: SeverityError,
+			Message: "no story points; estimate the story or split out a spike",
+		}}
+	}
+
+	points := us.Estimate.Points
+	switch {
+	case c.Velocity > 0 && float64(points) > c.Velocity:
+		return []Warning{{
+			StoryID: us.ID, Rule: RuleTooLarge, Principle: Small, Severity: SeverityError,
+			Message: fmt.Sprintf("%d points exceed the velocity of %.0f; split the story", points, c.Velocity),
+		}}
+	case c.Velocity > 0 && c.SmallShare > 0 && float64(points) > c.Velocity*c.SmallShare:
+		return []Warning{{
+			StoryID: us.ID, Rule: RuleTooLarge, Principle: Small, Severity: SeverityWarning,
+			Message: fmt.Sprintf("%d points take more than %.0f%% of the velocity of %.0f; consider splitting", points, c.SmallShare*100, c.Velocity),
+		}}
+	case c.Velocity <= 0 && c.MaxPoints > 0 && points > c.MaxPoints:
+		return []Warning{{
+			StoryID: us.ID, Rule: RuleTooLarge, Principle: Small, Severity: SeverityWarning,
+			Message: fmt.Sprintf("%d points exceed %d; consider splitting", points, c.MaxPoints),
+		}}
+	}
+	return nil
+}
+
+// cyclicStories returns the stories that are part of a dependency cycle
+func cyclicStories(deps map[string][]string) map[string]bool {
+	cyclic := make(map[string]bool)
+	for start := range deps {
+		// A story is cyclic when it can reach itself
+		visited := make(map[string]bool)
+		stack := append([]string(nil), deps[start]...)
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if id == start {
+				cyclic[start] = true
+				break
+			}
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			stack = append(stack, deps[id]...)
+		}
+	}
+	return cyclic
+}
@@ -0,0 +1,98 @@
+package backlog
+
+import (
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+// rules returns the rules of the findings
+func rules(a Assessment) []Rule {
+	var r []Rule
+	for _, f := range a.Findings {
+		r = append(r, f.Rule)
+	}
+	return r
+}
+
+func TestParseTemplate(t *testing.T) {
+	cases := []struct {
+		description string
+		ok          bool
+		role, value string
+	}{
+		{"As a shopper, I want to save my cart, so that I can buy later.", true, "shopper", "I can buy later"},
+		{"As an admin I want user reports", true, "admin", ""},
+		{"作为买家，我想要保存购物车，以便稍后购买。", true, "买家", "稍后购买"},
+		{"作为管理员，我希望导出报表", true, "管理员", ""},
+		{"Save the cart", false, "", ""},
+	}
+	for _, c := range cases {
+		tpl, ok := ParseTemplate(c.description)
+		if ok != c.ok || tpl.Role != c.role || tpl.Value != c.value {
+			t.Errorf("Expected %q to give %v/%q/%q, got %v/%+v", c.description, c.ok, c.role, c.value, ok, tpl)
+		}
+	}
+}
+
+func TestCheckerCheck(t *testing.T) {
+	good := newStory("good", 5, 3)
+	good.Update(good.Title, "As a shopper, I want to save my cart, so that I can buy later", good.Priority, 5)
+	good.AddAcceptanceCriterion("Given a cart, when I leave, then it is saved")
+
+	big := newStory("big", 5, 13)
+	big.Update(big.Title, "作为买家，我想要批量下单", big.Priority, 5)
+	big.AddAcceptanceCriterion("给定购物车，当我下单，那么生成订单")
+
+	rough := newStory("rough", 5, 0)
+
+	tasks := []*models.DevTask{
+		{ID: "g1", StoryID: "good", Dependencies: []string{"b1"}},
+		{ID: "b1", StoryID: "big"},
+	}
+
+	assessments := NewChecker(20).Check([]*models.UserStory{good, big, rough}, tasks)
+	if len(assessments) != 3 {
+		t.Fatalf("Expected 3 assessments, got %d", len(assessments))
+	}
+
+	// good only depends on an unfinished story
+	if r := rules(assessments[0]); len(r) != 1 || r[0] != RuleDependency || assessments[0].Score != 95 || !assessments[0].Ready {
+		t.Errorf("Expected good to score 95 with one dependency note, got %d and %v", assessments[0].Score, r)
+	}
+
+	// big misses the value clause and takes over half the velocity
+	if r := rules(assessments[1]); len(r) != 2 || r[0] != RuleValueMissing || r[1] != RuleTooLarge || assessments[1].Score != 70 {
+		t.Errorf("Expected big to score 70 for value and size, got %d and %v", assessments[1].Score, r)
+	}
+
+	// rough has no template, no estimate and no criteria and is not ready
+	if r := rules(assessments[2]); len(r) != 3 || assessments[2].Ready || assessments[2].Score != 25 {
+		t.Errorf("Expected rough to score 25 and not be ready, got %d and %v", assessments[2].Score, r)
+	}
+}
+
+func TestCheckerDependencyCycle(t *testing.T) {
+	a := newStory("a", 5, 3)
+	b := newStory("b", 5, 3)
+	tasks := []*models.DevTask{
+		{ID: "a1", StoryID: "a", Dependencies: []string{"b1"}},
+		{ID: "b1", StoryID: "b", Dependencies: []string{"a2"}},
+		{ID: "a2", StoryID: "a"},
+	}
+
+	assessments := NewChecker(0).Check([]*models.UserStory{a, b}, tasks)
+
+	// Both stories of the cycle are flagged as errors
+	for _, as := range assessments {
+		found := false
+		for _, f := range as.Findings {
+			if f.Rule == RuleDependencyCycle && f.Severity == SeverityError {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s to be flagged as cyclic, got %v", as.StoryID, rules(as))
+		}
+	}
+}
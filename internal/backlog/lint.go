@@ -0,0 +1,91 @@
+package backlog
+
+import (
+	"fmt"
+
+	"egodteam/internal/data/models"
+)
+
+// Principle is one letter of INVEST
+type Principle string
+
+const (
+	Independent Principle = "Independent"
+	Negotiable  Principle = "Negotiable"
+	Valuable    Principle = "Valuable"
+	Estimable   Principle = "Estimable"
+	Small       Principle = "Small"
+	Testable    Principle = "Testable"
+)
+
+// Severity ranks how much a finding matters; errors keep a story from being Ready
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Rule identifies a lint check
+type Rule string
+
+const (
+	RuleCriteriaMissing   Rule = "criteria-missing"
+	RuleCriteriaMalformed Rule = "criteria-malformed"
+)
+
+// Warning is a lint finding on a story
+type Warning struct {
+	StoryID   string    `json:"story_id"`
+	Rule      Rule      `json:"rule"`
+	Principle Principle `json:"principle"`
+	Severity  Severity  `json:"severity"`
+	Message   string    `json:"message"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s/%s]", w.StoryID, w.Severity, w.Message, w.Principle, w.Rule)
+}
+
+// LintCriteria warns about stories without acceptance criteria or with criteria
+// that are not valid Given/When/Then
+func LintCriteria(us *models.UserStory) []Warning {
+	if len(us.AcceptanceCriteria) == 0 {
+		return []Warning{{
+			StoryID:   us.ID,
+			Rule:      RuleCriteriaMissing,
+			Principle: Testable,
+			Severity:  SeverityError,
+			Message:   "no acceptance criteria",
+		}}
+	}
+
+	var warnings []Warning
+	_, errs := us.Scenarios()
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			StoryID:   us.ID,
+			Rule:      RuleCriteriaMalformed,
+			Principle: Testable,
+			Severity:  SeverityWarning,
+			Message:   fmt.Sprintf("criterion %d: %v", i+1, err),
+		})
+	}
+	return warnings
+}
+
+// Lint checks the acceptance criteria of the stories, skipping Done ones; Checker runs all INVEST checks
+func Lint(stories ...*models.UserStory) []Warning {
+	var warnings []Warning
+	for _, us := range stories {
+		if us.Status == models.StoryDone {
+			continue
+		}
+		warnings = append(warnings, LintCriteria(us)...)
+	}
+	return warnings
+}
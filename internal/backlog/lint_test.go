@@ -0,0 +1,30 @@
+package backlog
+
+import (
+	"strings"
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+func TestLintCriteria(t *testing.T) {
+	missing := newStory("missing", 5, 3)
+	malformed := newStory("malformed", 5, 3)
+	malformed.AddAcceptanceCriterion("Given a user, when they log in, then they see the dashboard")
+	malformed.AddAcceptanceCriterion("Login works")
+	done := newStory("done", 5, 3)
+	done.Status = models.StoryDone
+
+	warnings := Lint(missing, malformed, done)
+
+	// Check one warning per problem, none for done stories
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", warnings)
+	}
+	if warnings[0].StoryID != "missing" || warnings[0].Rule != RuleCriteriaMissing || warnings[0].Principle != Testable {
+		t.Errorf("Expected missing criteria on 'missing', got %v", warnings[0])
+	}
+	if warnings[1].StoryID != "malformed" || warnings[1].Rule != RuleCriteriaMalformed || !strings.HasPrefix(warnings[1].Message, "criterion 2:") {
+		t.Errorf("Expected malformed criterion 2 on 'malformed', got %v", warnings[1])
+	}
+}
@@ -0,0 +1,183 @@
+// Package backlog provides product backlog tooling for the PO agent
+// in the agile team intelligent agent system.
+package backlog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// Method identifies a prioritization scoring method
+type Method string
+
+const (
+	// MethodWSJF scores cost of delay (value + time criticality + risk reduction) over job size
+	MethodWSJF Method = "wsjf"
+	// MethodValueEffort scores business value over story points
+	MethodValueEffort Method = "value-effort"
+	// MethodMoSCoW orders by MoSCoW category, then by value over effort
+	MethodMoSCoW Method = "moscow"
+)
+
+// unestimatedSize is the job size assumed for stories without points, so they sink rather than float
+const unestimatedSize = 13
+
+// moscowBase separates MoSCoW categories so that no value/effort score crosses a category
+var moscowBase = map[models.MoSCoW]float64{
+	models.MoSCoWMust:   3000,
+	models.MoSCoWShould: 2000,
+	models.MoSCoWCould:  1000,
+	models.MoSCoWWont:   0,
+}
+
+// Ranker computes scores and orders the backlog
+type Ranker struct {
+	Method        Method
+	AgeWeight     float64          // Score added per week since creation, keeps old stories from starving
+	EnablerWeight float64          // Score added per story depending on this one
+	Now           func() time.Time // Defaults to time.Now
+}
+
+// NewRanker creates a ranker with small age and enabler bonuses
+func NewRanker(method Method) *Ranker {
+	return &Ranker{
+		Method:        method,
+		AgeWeight:     0.1,
+		EnablerWeight: 0.5,
+		Now:           time.Now,
+	}
+}
+
+// Ranked is a story's position in the ordered backlog with the reasoning behind it
+type Ranked struct {
+	Story        *models.UserStory `json:"-"`
+	StoryID      string            `json:"story_id"`
+	Score        float64           `json:"score"`
+	Rank         int               `json:"rank"`
+	PreviousRank int               `json:"previous_rank"` // 0 if the story was not ranked before
+	Explanation  string            `json:"explanation"`
+}
+
+// Rank scores every story that is not Done or split, sorts them by descending score and stores
+// the new position with SetRank. Tasks are used to find stories other stories depend on.
+func (r *Ranker) Rank(stories []*models.UserStory, tasks []*models.DevTask) []Ranked {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+
+	// Count how many stories wait on each story
+	dependents := make(map[string]int)
+	for _, deps := range models.StoryDependencies(tasks) {
+		for _, id := range deps {
+			dependents[id]++
+		}
+	}
+
+	var ranked []Ranked
+	for _, us := range stories {
+		if us.Status == models.StoryDone || us.IsSplit() {
+			continue
+		}
+
+		score, parts := r.score(us)
+		if weeks := now().Sub(us.CreatedAt).Hours() / (24 * 7); weeks >= 1 && r.AgeWeight > 0 {
+			score += weeks * r.AgeWeight
+			parts = append(parts, fmt.Sprintf("age %.0f weeks", weeks))
+		}
+		if n := dependents[us.ID]; n > 0 && r.EnablerWeight > 0 {
+			score += float64(n) * r.EnablerWeight
+			parts = append(parts, fmt.Sprintf("enables %d stories", n))
+		}
+
+		ranked = append(ranked, Ranked{
+			Story:        us,
+			StoryID:      us.ID,
+			Score:        score,
+			PreviousRank: us.Rank,
+			Explanation:  strings.Join(parts, ", "),
+		})
+	}
+
+	// Ties keep the previous order so re-ranking is stable
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return previous(ranked[i]) < previous(ranked[j])
+	})
+
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+		ranked[i].Explanation = fmt.Sprintf("score %.2f (%s); %s", ranked[i].Score, ranked[i].Explanation, movement(ranked[i]))
+		if ranked[i].Story.Rank != ranked[i].Rank {
+			ranked[i].Story.SetRank(ranked[i].Rank)
+		}
+	}
+
+	return ranked
+}
+
+// score applies the ranker's method and describes the inputs used
+func (r *Ranker) score(us *models.UserStory) (float64, []string) {
+	size, sizeNote := jobSize(us)
+
+	switch r.Method {
+	case MethodWSJF:
+		costOfDelay := us.BusinessValue + us.TimeCriticality + us.RiskReduction
+		return float64(costOfDelay) / float64(size), []string{
+			fmt.Sprintf("cost of delay %d = value %d + time criticality %d + risk reduction %d",
+				costOfDelay, us.BusinessValue, us.TimeCriticality, us.RiskReduction),
+			sizeNote,
+		}
+	case MethodMoSCoW:
+		category := us.MoSCoW
+		if category == "" {
+			category = models.MoSCoWCould
+		}
+		return moscowBase[category] + float64(us.BusinessValue)/float64(size), []string{
+			fmt.Sprintf("MoSCoW %s", category),
+			fmt.Sprintf("value %d", us.BusinessValue),
+			sizeNote,
+		}
+	default:
+		return float64(us.BusinessValue) / float64(size), []string{
+			fmt.Sprintf("value %d", us.BusinessValue),
+			sizeNote,
+		}
+	}
+}
+
+// jobSize returns the story points used as effort, with unestimated stories assumed large
+func jobSize(us *models.UserStory) (int, string) {
+	if us.Estimate == nil || us.Estimate.Points <= 0 {
+		return unestimatedSize, fmt.Sprintf("unestimated, assumed %d points", unestimatedSize)
+	}
+	return us.Estimate.Points, fmt.Sprintf("%d points", us.Estimate.Points)
+}
+
+// previous returns the previous rank, with unranked stories sorting last
+func previous(r Ranked) int {
+	if r.PreviousRank <= 0 {
+		return int(^uint(0) >> 1)
+	}
+	return r.PreviousRank
+}
+
+// movement describes the change from the previous rank
+func movement(r Ranked) string {
+	switch {
+	case r.PreviousRank <= 0:
+		return fmt.Sprintf("new at #%d", r.Rank)
+	case r.PreviousRank > r.Rank:
+		return fmt.Sprintf("up from #%d to #%d", r.PreviousRank, r.Rank)
+	case r.PreviousRank < r.Rank:
+		return fmt.Sprintf("down from #%d to #%d", r.PreviousRank, r.Rank)
+	default:
+		return fmt.Sprintf("unchanged at #%d", r.Rank)
+	}
+}
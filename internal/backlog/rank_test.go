@@ -0,0 +1,131 @@
+package backlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// newStory creates a story with the given ID, business value and points
+func newStory(id string, value, points int) *models.UserStory {
+	us := models.NewUserStory("Story "+id, "")
+	us.ID = id
+	us.Update(us.Title, us.Description, us.Priority, value)
+	us.SetEstimate(points)
+	return us
+}
+
+func TestRankValueEffort(t *testing.T) {
+	cheap := newStory("cheap", 6, 2)       // 3.0
+	valuable := newStory("valuable", 9, 5) // 1.8
+	unestimated := newStory("unestimated", 10, 0)
+	done := newStory("done", 10, 1)
+	done.Status = models.StoryDone
+
+	ranked := NewRanker(MethodValueEffort).Rank([]*models.UserStory{valuable, unestimated, done, cheap}, nil)
+
+	// Check the order and that done stories are left out
+	expected := []string{"cheap", "valuable", "unestimated"}
+	if len(ranked) != len(expected) {
+		t.Fatalf("Expected %d ranked stories, got %d", len(expected), len(ranked))
+	}
+	for i, id := range expected {
+		if ranked[i].StoryID != id || ranked[i].Rank != i+1 {
+			t.Errorf("Expected #%d to be %s, got %s at #%d", i+1, id, ranked[i].StoryID, ranked[i].Rank)
+		}
+	}
+
+	// Check that ranks were written back to the stories
+	if cheap.Rank != 1 || unestimated.Rank != 3 || done.Rank != 0 {
+		t.Errorf("Expected ranks 1, 3 and 0, got %d, %d and %d", cheap.Rank, unestimated.Rank, done.Rank)
+	}
+	if !strings.Contains(ranked[2].Explanation, "unestimated, assumed 13 points") {
+		t.Errorf("Expected the unestimated story to be explained, got %q", ranked[2].Explanation)
+	}
+}
+
+func TestRankWSJF(t *testing.T) {
+	urgent := newStory("urgent", 3, 3)
+	urgent.SetScoring(9, 3, "")
+	plain := newStory("plain", 8, 3)
+
+	ranked := NewRanker(MethodWSJF).Rank([]*models.UserStory{plain, urgent}, nil)
+
+	// Time criticality and risk reduction outweigh raw business value
+	if ranked[0].StoryID != "urgent" {
+		t.Errorf("Expected urgent first, got %s", ranked[0].StoryID)
+	}
+	if ranked[0].Score != 5 {
+		t.Errorf("Expected WSJF score 5, got %v", ranked[0].Score)
+	}
+	if !strings.Contains(ranked[0].Explanation, "cost of delay 15") {
+		t.Errorf("Expected cost of delay in the explanation, got %q", ranked[0].Explanation)
+	}
+}
+
+func TestRankMoSCoW(t *testing.T) {
+	must := newStory("must", 1, 8)
+	must.SetScoring(0, 0, models.MoSCoWMust)
+	could := newStory("could", 10, 1)
+	wont := newStory("wont", 10, 1)
+	wont.SetScoring(0, 0, models.MoSCoWWont)
+
+	ranked := NewRanker(MethodMoSCoW).Rank([]*models.UserStory{wont, could, must}, nil)
+
+	// Categories dominate value and effort; missing categories count as Could
+	expected := []string{"must", "could", "wont"}
+	for i, id := range expected {
+		if ranked[i].StoryID != id {
+			t.Errorf("Expected #%d to be %s, got %s", i+1, id, ranked[i].StoryID)
+		}
+	}
+}
+
+func TestRankAgeAndEnablers(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	old := newStory("old", 5, 5)
+	old.CreatedAt = now.AddDate(0, 0, -70)
+	enabler := newStory("enabler", 5, 5)
+	enabler.CreatedAt = now
+	other := newStory("other", 5, 5)
+	other.CreatedAt = now
+
+	tasks := []*models.DevTask{
+		{ID: "e1", StoryID: "enabler"},
+		{ID: "o1", StoryID: "other", Dependencies: []string{"e1"}},
+	}
+
+	r := NewRanker(MethodValueEffort)
+	r.Now = func() time.Time { return now }
+	ranked := r.Rank([]*models.UserStory{other, enabler, old}, tasks)
+
+	// Ten weeks of age (+1.0) beat one dependent story (+0.5)
+	expected := []string{"old", "enabler", "other"}
+	for i, id := range expected {
+		if ranked[i].StoryID != id {
+			t.Errorf("Expected #%d to be %s, got %s", i+1, id, ranked[i].StoryID)
+		}
+	}
+	if !strings.Contains(ranked[1].Explanation, "enables 1 stories") {
+		t.Errorf("Expected enabler bonus in the explanation, got %q", ranked[1].Explanation)
+	}
+}
+
+func TestRankExplainsMovement(t *testing.T) {
+	a := newStory("a", 5, 5)
+	b := newStory("b", 4, 5)
+	r := NewRanker(MethodValueEffort)
+	r.Rank([]*models.UserStory{a, b}, nil)
+
+	// Raising b's value moves it above a
+	b.Update(b.Title, b.Description, b.Priority, 9)
+	ranked := r.Rank([]*models.UserStory{a, b}, nil)
+	if !strings.HasSuffix(ranked[0].Explanation, "up from #2 to #1") {
+		t.Errorf("Expected b to move up, got %q", ranked[0].Explanation)
+	}
+	if !strings.HasSuffix(ranked[1].Explanation, "down from #1 to #2") {
+		t.Errorf("Expected a to move down, got %q", ranked[1].Explanation)
+	}
+}
@@ -0,0 +1,270 @@
+package backlog
+
+import (
+	"fmt"
+	"strings"
+
+	"egodteam/internal/data/models"
+)
+
+// backlogError implements the error interface
+type backlogError string
+
+func (e backlogError) Error() string {
+	return string(e)
+}
+
+// ErrCannotSplit is returned when a story is Done or was already split
+var ErrCannotSplit = backlogError("story cannot be split")
+
+// ErrInvalidSplit is returned when the parts do not cover the story
+var ErrInvalidSplit = backlogError("invalid split")
+
+// SplitStrategy identifies how a story was split
+type SplitStrategy string
+
+const (
+	// SplitByCriteria makes one story per acceptance criterion
+	SplitByCriteria SplitStrategy = "criteria"
+	// SplitByWorkflow makes one story per step of the user's workflow
+	SplitByWorkflow SplitStrategy = "workflow"
+	// SplitByData makes one story per data variation, such as the examples of a scenario outline
+	SplitByData SplitStrategy = "data"
+)
+
+// Part describes one child story of a split
+type Part struct {
+	Title       string
+	Description string   // Defaults to the parent's description
+	Criteria    []int    // Indexes of the parent's criteria the child takes over
+	Replaces    []int    // Indexes of the parent's criteria that Extra restates for this child
+	Extra       []string // Criteria only this child has
+	Points      int      // 0 to share the parent's points by number of criteria
+}
+
+// SplitResult is the outcome of a split
+type SplitResult struct {
+	Strategy SplitStrategy       `json:"strategy"`
+	Parent   *models.UserStory   `json:"parent"`
+	Children []*models.UserStory `json:"children"`
+}
+
+// Split replaces the story by one child per part. Every criterion of the parent must be taken
+// over or replaced by at least one part. Children inherit the parent's epic and prioritization, and are made
+// Ready when the parent was past Draft and they qualify. Children are tracked by the parent's
+// recorder. The parent keeps its status and records its children, which takes it out of
+// ranking and planning.
+func Split(parent *models.UserStory, strategy SplitStrategy, parts []Part) (*SplitResult, error) {
+	if parent.Status == models.StoryDone || parent.IsSplit() {
+		return nil, fmt.Errorf("%w: %s", ErrCannotSplit, parent.ID)
+	}
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("%w: need at least two parts, got %d", ErrInvalidSplit, len(parts))
+	}
+
+	covered := make([]bool, len(parent.AcceptanceCriteria))
+	explicit := 0
+	for i, part := range parts {
+		if strings.TrimSpace(part.Title) == "" {
+			return nil, fmt.Errorf("%w: part %d has no title", ErrInvalidSplit, i+1)
+		}
+		for _, c := range append(append([]int(nil), part.Criteria...), part.Replaces...) {
+			if c < 0 || c >= len(covered) {
+				return nil, fmt.Errorf("%w: part %d refers to criterion %d", ErrInvalidSplit, i+1, c)
+			}
+			covered[c] = true
+		}
+		if part.Points > 0 {
+			explicit++
+		}
+	}
+	for i, ok := range covered {
+		if !ok {
+			return nil, fmt.Errorf("%w: criterion %d is not covered", ErrInvalidSplit, i)
+		}
+	}
+	if explicit != 0 && explicit != len(parts) {
+		return nil, fmt.Errorf("%w: points must be given for all parts or none", ErrInvalidSplit)
+	}
+
+	points := shareIntoParts(parent, parts)
+	result := &SplitResult{Strategy: strategy, Parent: parent}
+	for i, part := range parts {
+		description := part.Description
+		if description == "" {
+			description = parent.Description
+		}
+
+		child := models.NewUserStory(part.Title, description)
+		child.Track(parent.Recorder())
+		child.Update(child.Title, child.Description, parent.Priority, parent.BusinessValue)
+		for _, c := range part.Criteria {
+			child.AddAcceptanceCriterion(parent.AcceptanceCriteria[c])
+		}
+		for _, criterion := range part.Extra {
+			child.AddAcceptanceCriterion(criterion)
+		}
+		if points[i] > 0 {
+			child.SetEstimate(points[i])
+		}
+		if parent.EpicID != "" {
+			child.SetEpic(parent.EpicID)
+		}
+		if parent.TimeCriticality != 0 || parent.RiskReduction != 0 || parent.MoSCoW != "" {
+			child.SetScoring(parent.TimeCriticality, parent.RiskReduction, parent.MoSCoW)
+		}
+		child.SetParent(parent.ID)
+		if parent.Status != models.StoryDraft {
+			// Children failing the Ready guard stay Draft and show up in planning flags
+			child.SetStatus(models.StoryReady)
+		}
+
+		parent.AddChild(child.ID)
+		result.Children = append(result.Children, child)
+	}
+	return result, nil
+}
+
+// shareIntoParts returns each part's points, sharing the parent's points by number of criteria
+// unless the parts give their own. Shares use largest remainders so they add up to the parent.
+func shareIntoParts(parent *models.UserStory, parts []Part) []int {
+	points := make([]int, len(parts))
+	if parts[0].Points > 0 {
+		for i, part := range parts {
+			points[i] = part.Points
+		}
+		return points
+	}
+	if parent.Estimate == nil || parent.Estimate.Points <= 0 {
+		return points
+	}
+	total := parent.Estimate.Points
+
+	weights := make([]int, len(parts))
+	sum := 0
+	for i, part := range parts {
+		weights[i] = len(part.Criteria) + len(part.Extra)
+		if weights[i] == 0 {
+			weights[i] = 1
+		}
+		sum += weights[i]
+	}
+
+	given := 0
+	remainders := make([]int, len(parts))
+	for i, w := range weights {
+		points[i] = total * w / sum
+		remainders[i] = total * w % sum
+		given += points[i]
+	}
+	for ; given < total; given++ {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		points[best]++
+		remainders[best] = -1
+	}
+	return points
+}
+
+// SplitByCriteriaParts makes one part per acceptance criterion, titled after the parent
+func SplitByCriteriaParts(parent *models.UserStory) []Part {
+	parts := make([]Part, 0, len(parent.AcceptanceCriteria))
+	for i, criterion := range parent.AcceptanceCriteria {
+		title := fmt.Sprintf("%s (%d)", parent.Title, i+1)
+		if s, err := models.ParseScenario(criterion); err == nil && s.Name != "" {
+			title = fmt.Sprintf("%s: %s", parent.Title, s.Name)
+		}
+		parts = append(parts, Part{Title: title, Criteria: []int{i}})
+	}
+	return parts
+}
+
+// SplitByExamplesParts makes one part per example row of the scenario outline at the given
+// criterion index. Each part replaces the outline by the concrete scenario for its row and keeps
+// every other criterion.
+func SplitByExamplesParts(parent *models.UserStory, criterion int) ([]Part, error) {
+	if criterion < 0 || criterion >= len(parent.AcceptanceCriteria) {
+		return nil, fmt.Errorf("%w: no criterion %d", ErrInvalidSplit, criterion)
+	}
+	outline, err := models.ParseScenario(parent.AcceptanceCriteria[criterion])
+	if err != nil {
+		return nil, err
+	}
+	if !outline.Outline {
+		return nil, fmt.Errorf("%w: criterion %d is not a scenario outline", ErrInvalidSplit, criterion)
+	}
+
+	var shared []int
+	for i := range parent.AcceptanceCriteria {
+		if i != criterion {
+			shared = append(shared, i)
+		}
+	}
+
+	parts := make([]Part, 0, len(outline.Examples.Rows))
+	for _, row := range outline.Examples.Rows {
+		concrete := models.Scenario{Language: outline.Language, Name: outline.Name}
+		for _, step := range outline.Steps {
+			text := step.Text
+			for i, name := range outline.Examples.Header {
+				text = strings.ReplaceAll(text, "<"+name+">", row[i])
+			}
+			concrete.Steps = append(concrete.Steps, models.Step{Keyword: step.Keyword, Text: text})
+		}
+		if concrete.Name == "" {
+			concrete.Name = strings.Join(row, ", ")
+		} else {
+			concrete.Name += " (" + strings.Join(row, ", ") + ")"
+		}
+
+		parts = append(parts, Part{
+			Title:    fmt.Sprintf("%s (%s)", parent.Title, strings.Join(row, ", ")),
+			Criteria: shared,
+			Replaces: []int{criterion},
+			Extra:    []string{concrete.String()},
+		})
+	}
+	return parts, nil
+}
+
+// Retarget moves the references to the parent onto its children: sprints committing the parent
+// commit every child instead, and the parent's tasks move to the child chosen by choose,
+// or the first child when choose is nil or returns nil. Closed sprints are left untouched.
+func (r *SplitResult) Retarget(sprints []*models.Sprint, tasks []*models.DevTask, choose func(*models.DevTask) *models.UserStory) {
+	for _, s := range sprints {
+		if s.Status == models.SprintClosed || !containsID(s.Committed, r.Parent.ID) {
+			continue
+		}
+		s.RemoveCommittedStory(r.Parent.ID)
+		for _, child := range r.Children {
+			s.AddCommittedStory(child.ID)
+		}
+	}
+
+	for _, task := range tasks {
+		if task.StoryID != r.Parent.ID {
+			continue
+		}
+		target := r.Children[0]
+		if choose != nil {
+			if chosen := choose(task); chosen != nil {
+				target = chosen
+			}
+		}
+		task.SetStory(target.ID)
+	}
+}
+
+// containsID reports whether the slice holds the ID
+func containsID(ids []string, id string) bool {
+	for _, s := range ids {
+		if s == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package backlog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+func TestSplitRecordsChildren(t *testing.T) {
+	events := store.New()
+	parent := newStory("checkout", 8, 5)
+	parent.AddAcceptanceCriterion("Pays by card")
+	parent.AddAcceptanceCriterion("Pays by voucher")
+	parent.SetStatus(models.StoryReady)
+	parent.Track(events)
+
+	result, err := Split(parent, SplitByCriteria, SplitByCriteriaParts(parent))
+	if err != nil {
+		t.Fatalf("Failed to split: %v", err)
+	}
+
+	// Check that the projected parent points at children that exist with their fields
+	state := events.State()
+	projected := state.Stories[parent.ID]
+	if len(projected.Children) != 2 {
+		t.Fatalf("Expected the projected parent to have 2 children, got %v", projected.Children)
+	}
+	for i, id := range projected.Children {
+		child, ok := state.Stories[id]
+		if !ok {
+			t.Fatalf("Expected child %s in the projection", id)
+		}
+		want := result.Children[i]
+		if child.ParentID != parent.ID || child.Status != models.StoryReady || child.Estimate.Points != want.Estimate.Points || len(child.AcceptanceCriteria) != 1 {
+			t.Errorf("Expected the projected child to match %+v, got %+v", want, child)
+		}
+	}
+}
+
+func TestSplitByCriteria(t *testing.T) {
+	parent := newStory("checkout", 8, 5)
+	parent.SetEpic("epic-1")
+	parent.AddAcceptanceCriterion("Scenario: Card\nGiven a cart\nWhen I pay by card\nThen the order is placed")
+	parent.AddAcceptanceCriterion("Scenario: Voucher\nGiven a cart\nWhen I pay by voucher\nThen the order is placed")
+	parent.SetStatus(models.StoryReady)
+
+	result, err := Split(parent, SplitByCriteria, SplitByCriteriaParts(parent))
+	if err != nil {
+		t.Fatalf("Failed to split: %v", err)
+	}
+
+	// Check one child per criterion, named after the scenario
+	if len(result.Children) != 2 || result.Children[1].Title != "Story checkout: Voucher" {
+		t.Fatalf("Expected 2 children ending with the voucher scenario, got %d", len(result.Children))
+	}
+
+	// Check that the points add up to the parent's by largest remainder
+	if a, b := result.Children[0].Estimate.Points, result.Children[1].Estimate.Points; a != 3 || b != 2 {
+		t.Errorf("Expected points 3 and 2, got %d and %d", a, b)
+	}
+
+	// Check lineage, inherited fields and status
+	for _, child := range result.Children {
+		if child.ParentID != parent.ID || child.EpicID != "epic-1" || child.BusinessValue != 8 {
+			t.Errorf("Expected child to inherit parent, epic and value, got %+v", child)
+		}
+		if child.Status != models.StoryReady || len(child.AcceptanceCriteria) != 1 {
+			t.Errorf("Expected a Ready child with one criterion, got %s with %d", child.Status, len(child.AcceptanceCriteria))
+		}
+	}
+	if len(parent.Children) != 2 || !parent.IsSplit() {
+		t.Errorf("Expected the parent to record 2 children, got %v", parent.Children)
+	}
+
+	// Check that a split story cannot be split again
+	if _, err := Split(parent, SplitByCriteria, SplitByCriteriaParts(parent)); !errors.Is(err, ErrCannotSplit) {
+		t.Errorf("Expected ErrCannotSplit, got %v", err)
+	}
+}
+
+func TestSplitInvalidParts(t *testing.T) {
+	parent := newStory("search", 5, 8)
+	parent.AddAcceptanceCriterion("Given products, when I search, then I see matches")
+	parent.AddAcceptanceCriterion("Given no match, when I search, then I see suggestions")
+
+	cases := map[string][]Part{
+		"one part":          {{Title: "All", Criteria: []int{0, 1}}},
+		"uncovered":         {{Title: "A", Criteria: []int{0}}, {Title: "B"}},
+		"unknown criterion": {{Title: "A", Criteria: []int{0, 1}}, {Title: "B", Criteria: []int{2}}},
+		"no title":          {{Title: "A", Criteria: []int{0}}, {Criteria: []int{1}}},
+		"partial points":    {{Title: "A", Criteria: []int{0}, Points: 3}, {Title: "B", Criteria: []int{1}}},
+	}
+	for name, parts := range cases {
+		if _, err := Split(parent, SplitByWorkflow, parts); !errors.Is(err, ErrInvalidSplit) {
+			t.Errorf("Expected ErrInvalidSplit for %s, got %v", name, err)
+		}
+	}
+
+	// Check that a failed split leaves the parent alone
+	if parent.IsSplit() {
+		t.Errorf("Expected the parent not to be split, got %v", parent.Children)
+	}
+}
+
+func TestSplitByExamples(t *testing.T) {
+	parent := newStory("register", 5, 6)
+	parent.AddAcceptanceCriterion("Given a visitor\nWhen they open the form\nThen they see the rules")
+	parent.AddAcceptanceCriterion(`Scenario Outline: Password rules
+  Given the password <password>
+  When the user registers
+  Then the result is <result>
+
+  Examples:
+    | password | result   |
+    | abc      | rejected |
+    | Abc12345 | accepted |`)
+
+	// A criterion that is not an outline cannot be split by data
+	if _, err := SplitByExamplesParts(parent, 0); !errors.Is(err, ErrInvalidSplit) {
+		t.Errorf("Expected ErrInvalidSplit for a plain scenario, got %v", err)
+	}
+
+	parts, err := SplitByExamplesParts(parent, 1)
+	if err != nil {
+		t.Fatalf("Failed to make parts: %v", err)
+	}
+	result, err := Split(parent, SplitByData, parts)
+	if err != nil {
+		t.Fatalf("Failed to split: %v", err)
+	}
+
+	// Check one child per example row with the shared criterion and a concrete scenario
+	if len(result.Children) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(result.Children))
+	}
+	second := result.Children[1]
+	if len(second.AcceptanceCriteria) != 2 || second.AcceptanceCriteria[0] != parent.AcceptanceCriteria[0] {
+		t.Fatalf("Expected the shared criterion and one scenario, got %v", second.AcceptanceCriteria)
+	}
+	scenario := second.AcceptanceCriteria[1]
+	if !strings.Contains(scenario, "Given the password Abc12345") || strings.Contains(scenario, "<") {
+		t.Errorf("Expected a concrete scenario for Abc12345, got %q", scenario)
+	}
+	if _, err := models.ParseScenario(scenario); err != nil {
+		t.Errorf("Expected the concrete scenario to parse, got %v", err)
+	}
+
+	// Check that the points are shared evenly
+	if second.Estimate.Points != 3 {
+		t.Errorf("Expected 3 points, got %d", second.Estimate.Points)
+	}
+}
+
+func TestSplitRetarget(t *testing.T) {
+	parent := newStory("report", 5, 8)
+	parent.AddAcceptanceCriterion("Given data, when I export, then I get a CSV")
+	parent.AddAcceptanceCriterion("Given data, when I export, then I get a PDF")
+
+	planned := &models.Sprint{ID: "sprint-2", Status: models.SprintPlanned, Committed: []string{"other", "report"}}
+	closed := &models.Sprint{ID: "sprint-1", Status: models.SprintClosed, Committed: []string{"report"}}
+	csv := &models.DevTask{ID: "t1", StoryID: "report", Title: "CSV writer"}
+	pdf := &models.DevTask{ID: "t2", StoryID: "report", Title: "PDF writer"}
+	unrelated := &models.DevTask{ID: "t3", StoryID: "other"}
+
+	result, err := Split(parent, SplitByWorkflow, []Part{
+		{Title: "CSV export", Criteria: []int{0}, Points: 3},
+		{Title: "PDF export", Criteria: []int{1}, Points: 5},
+	})
+	if err != nil {
+		t.Fatalf("Failed to split: %v", err)
+	}
+	result.Retarget([]*models.Sprint{planned, closed}, []*models.DevTask{csv, pdf, unrelated}, func(task *models.DevTask) *models.UserStory {
+		if strings.HasPrefix(task.Title, "PDF") {
+			return result.Children[1]
+		}
+		return nil
+	})
+
+	// Check that the open sprint commits the children instead of the parent
+	want := []string{"other", result.Children[0].ID, result.Children[1].ID}
+	if strings.Join(planned.Committed, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected committed %v, got %v", want, planned.Committed)
+	}
+
+	// Check that the closed sprint keeps its history
+	if len(closed.Committed) != 1 || closed.Committed[0] != "report" {
+		t.Errorf("Expected the closed sprint to be untouched, got %v", closed.Committed)
+	}
+
+	// Check that tasks moved to the chosen child, or the first one by default
+	if csv.StoryID != result.Children[0].ID || pdf.StoryID != result.Children[1].ID || unrelated.StoryID != "other" {
+		t.Errorf("Expected tasks on %s, %s and other, got %s, %s and %s",
+			result.Children[0].ID, result.Children[1].ID, csv.StoryID, pdf.StoryID, unrelated.StoryID)
+	}
+
+	// Check that the split parent is no longer ranked
+	for _, r := range NewRanker(MethodValueEffort).Rank([]*models.UserStory{parent, result.Children[0]}, nil) {
+		if r.StoryID == parent.ID {
+			t.Errorf("Expected the split parent not to be ranked")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package chart renders sprint burn-down and burn-up charts for the terminal
+// and as standalone SVG files in the agile team intelligent agent system.
+package chart
+
+import (
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// Kind identifies the type of chart
+type Kind string
+
+const (
+	BurnDown Kind = "burn-down"
+	BurnUp   Kind = "burn-up"
+)
+
+// Line is a named data series with one value per day
+type Line struct {
+	Name   string
+	Values []int
+	Glyph  rune   // Unicode glyph used in the terminal
+	ASCII  rune   // Fallback glyph for plain ASCII terminals
+	Color  string // SVG stroke color
+	Dashed bool
+}
+
+// Chart holds the lines of a chart over a shared range of days
+type Chart struct {
+	Title string
+	Kind  Kind
+	Days  []time.Time
+	Lines []Line
+}
+
+// NewBurnDown builds a burn-down chart with the actual remaining points against the ideal line
+func NewBurnDown(s *models.Sprint, until time.Time) *Chart {
+	ideal := s.IdealLine()
+	actual := s.DailyBurnDown(until)
+
+	c := &Chart{Title: s.Goal, Kind: BurnDown, Days: days(ideal)}
+	c.Lines = append(c.Lines,
+		Line{Name: "ideal", Values: values(ideal, left), Glyph: '·', ASCII: '.', Color: "#9e9e9e", Dashed: true},
+		Line{Name: "actual", Values: values(actual, left), Glyph: '●', ASCII: '*', Color: "#d32f2f"},
+	)
+	return c
+}
+
+// NewBurnUp builds a burn-up chart with completed points against the scope line
+func NewBurnUp(s *models.Sprint, until time.Time) *Chart {
+	actual := knownScope(s, s.DailyBurnDown(until))
+
+	c := &Chart{Title: s.Goal, Kind: BurnUp, Days: days(s.IdealLine())}
+	c.Lines = append(c.Lines,
+		Line{Name: "scope", Values: values(actual, scope), Glyph: '─', ASCII: '-', Color: "#1976d2", Dashed: true},
+		Line{Name: "done", Values: values(actual, done), Glyph: '●', ASCII: '*', Color: "#388e3c"},
+	)
+	return c
+}
+
+// knownScope fills in the scope of snapshots recorded without one, as by AddBurnDownPoint,
+// with the sprint's committed points, or with the most points left so far when no points
+// lookup is attached. The scope is never below the points left.
+func knownScope(s *models.Sprint, points []models.BurnDownPoint) []models.BurnDownPoint {
+	committed, ok := s.CommittedScope()
+	most := 0
+	out := make([]models.BurnDownPoint, len(points))
+	for i, p := range points {
+		if p.Left > most {
+			most = p.Left
+		}
+		if p.Scope == 0 {
+			p.Scope = most
+			if ok && committed > most {
+				p.Scope = committed
+			}
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// max returns the largest value across all lines, at least 1 to avoid dividing by zero
+func (c *Chart) max() int {
+	m := 1
+	for _, line := range c.Lines {
+		for _, v := range line.Values {
+			if v > m {
+				m = v
+			}
+		}
+	}
+	return m
+}
+
+func left(p models.BurnDownPoint) int  { return p.Left }
+func scope(p models.BurnDownPoint) int { return p.Scope }
+func done(p models.BurnDownPoint) int  { return p.Scope - p.Left }
+
+// values extracts one field of each point
+func values(points []models.BurnDownPoint, field func(models.BurnDownPoint) int) []int {
+	out := make([]int, len(points))
+	for i, p := range points {
+		out[i] = field(p)
+	}
+	return out
+}
+
+// days extracts the dates of the points
+func days(points []models.BurnDownPoint) []time.Time {
+	out := make([]time.Time, len(points))
+	for i, p := range points {
+		out[i] = p.Date
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// newTestSprint creates a five-day sprint with 8 points committed and burned down to 3
+func newTestSprint() *models.Sprint {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	s := models.NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+	s.BurnDown = []models.BurnDownPoint{
+		{Date: start, Left: 8, Scope: 8},
+		{Date: start.AddDate(0, 0, 1), Left: 6, Scope: 8},
+		{Date: start.AddDate(0, 0, 2), Left: 3, Scope: 10},
+	}
+	return s
+}
+
+func TestNewBurnDown(t *testing.T) {
+	s := newTestSprint()
+	c := NewBurnDown(s, s.StartDate.AddDate(0, 0, 2))
+
+	// Check that the chart spans the whole sprint with ideal and actual lines
+	if c.Kind != BurnDown || len(c.Days) != 5 {
+		t.Fatalf("Expected a 5-day burn-down, got %s over %d days", c.Kind, len(c.Days))
+	}
+	if len(c.Lines) != 2 || c.Lines[0].Name != "ideal" || c.Lines[1].Name != "actual" {
+		t.Fatalf("Expected ideal and actual lines, got %v", c.Lines)
+	}
+	if c.Lines[0].Values[0] != 8 || c.Lines[0].Values[4] != 0 {
+		t.Errorf("Expected ideal line from 8 to 0, got %v", c.Lines[0].Values)
+	}
+
+	// The actual line stops at the last day observed
+	actual := c.Lines[1].Values
+	if len(actual) != 3 || actual[2] != 3 {
+		t.Errorf("Expected actual values [8 6 3], got %v", actual)
+	}
+}
+
+func TestNewBurnUp(t *testing.T) {
+	s := newTestSprint()
+	c := NewBurnUp(s, s.StartDate.AddDate(0, 0, 2))
+
+	// Check that scope growth and completed points are both plotted
+	if len(c.Lines) != 2 || c.Lines[0].Name != "scope" || c.Lines[1].Name != "done" {
+		t.Fatalf("Expected scope and done lines, got %v", c.Lines)
+	}
+	if c.Lines[0].Values[2] != 10 {
+		t.Errorf("Expected scope of 10 on day 3, got %d", c.Lines[0].Values[2])
+	}
+	if c.Lines[1].Values[2] != 7 {
+		t.Errorf("Expected 7 done on day 3, got %d", c.Lines[1].Values[2])
+	}
+}
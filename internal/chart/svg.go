@@ -0,0 +1,113 @@
+package chart
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SVGOptions controls the size of a rendered SVG chart
+type SVGOptions struct {
+	Width  int // Defaults to 640
+	Height int // Defaults to 360
+}
+
+// svg layout margins in pixels
+const (
+	marginLeft   = 48
+	marginRight  = 16
+	marginTop    = 40
+	marginBottom = 48
+)
+
+// RenderSVG writes the chart as a standalone SVG document
+func (c *Chart) RenderSVG(w io.Writer, opts SVGOptions) error {
+	width, height := opts.Width, opts.Height
+	if width <= 0 {
+		width = 640
+	}
+	if height <= 0 {
+		height = 360
+	}
+	plotW := float64(width - marginLeft - marginRight)
+	plotH := float64(height - marginTop - marginBottom)
+	top := c.max()
+
+	x := func(day int) float64 {
+		if len(c.Days) < 2 {
+			return marginLeft
+		}
+		return marginLeft + plotW*float64(day)/float64(len(c.Days)-1)
+	}
+	y := func(v int) float64 {
+		if v < 0 {
+			v = 0
+		}
+		return marginTop + plotH - plotH*float64(v)/float64(top)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+	fmt.Fprintf(&b, `<text x="%d" y="24" font-size="16">%s (%s)</text>`+"\n", marginLeft, escape(c.Title), c.Kind)
+
+	// Axes with the value range and first/last day
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%.1f" stroke="#424242"/>`+"\n", marginLeft, marginTop, marginLeft, marginTop+plotH)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#424242"/>`+"\n", marginLeft, marginTop+plotH, marginLeft+plotW, marginTop+plotH)
+	fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%d</text>`+"\n", marginLeft-6, y(top)+4, top)
+	fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">0</text>`+"\n", marginLeft-6, y(0)+4)
+	if len(c.Days) > 0 {
+		last := len(c.Days) - 1
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="start">%s</text>`+"\n", x(0), marginTop+plotH+18, c.Days[0].Format("2006-01-02"))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end">%s</text>`+"\n", x(last), marginTop+plotH+18, c.Days[last].Format("2006-01-02"))
+	}
+
+	// One polyline per series, followed by its legend entry
+	for i, line := range c.Lines {
+		var points []string
+		for day, v := range line.Values {
+			if day >= len(c.Days) {
+				break
+			}
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(day), y(v)))
+		}
+		dash := ""
+		if line.Dashed {
+			dash = ` stroke-dasharray="6 4"`
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2"%s points="%s"/>`+"\n", line.Color, dash, strings.Join(points, " "))
+
+		legendX := marginLeft + i*96
+		legendY := height - 12
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="2"%s/>`+"\n", legendX, legendY-4, legendX+20, legendY-4, line.Color, dash)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", legendX+26, legendY, escape(line.Name))
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteSVGFile renders the chart into an SVG file at path
+func (c *Chart) WriteSVGFile(path string, opts SVGOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := c.RenderSVG(file, opts); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// escape returns text safe for use inside SVG elements
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package chart
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderSVG(t *testing.T) {
+	s := newTestSprint()
+	s.Goal = "Login & <signup>"
+	c := NewBurnDown(s, s.StartDate.AddDate(0, 0, 2))
+
+	var b strings.Builder
+	if err := c.RenderSVG(&b, SVGOptions{}); err != nil {
+		t.Fatalf("Failed to render chart: %v", err)
+	}
+	out := b.String()
+
+	// Check that the document is well-formed XML
+	decoder := xml.NewDecoder(strings.NewReader(out))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err.Error() != "EOF" {
+				t.Fatalf("Expected well-formed SVG, got error %v in:\n%s", err, out)
+			}
+			break
+		}
+	}
+
+	// Check the size defaults, one polyline per series and the escaped title
+	if !strings.Contains(out, `width="640" height="360"`) {
+		t.Errorf("Expected default size 640x360, got:\n%s", out)
+	}
+	if n := strings.Count(out, "<polyline"); n != 2 {
+		t.Errorf("Expected 2 polylines, got %d", n)
+	}
+	if !strings.Contains(out, "Login &amp; &lt;signup&gt;") {
+		t.Errorf("Expected escaped title, got:\n%s", out)
+	}
+}
+
+func TestWriteSVGFile(t *testing.T) {
+	s := newTestSprint()
+	c := NewBurnUp(s, s.StartDate.AddDate(0, 0, 2))
+	path := filepath.Join(t.TempDir(), "burn-up.svg")
+
+	if err := c.WriteSVGFile(path, SVGOptions{Width: 800, Height: 400}); err != nil {
+		t.Fatalf("Failed to write SVG file: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read SVG file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<svg") || !strings.Contains(string(data), `width="800"`) {
+		t.Errorf("Expected an 800px wide SVG document, got:\n%s", data)
+	}
+}
@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TerminalOptions controls how a chart is drawn as text
+type TerminalOptions struct {
+	Height int  // Number of plot rows, defaults to 10
+	ASCII  bool // Use plain ASCII glyphs instead of Unicode
+}
+
+// columnWidth is the number of characters used per day
+const columnWidth = 3
+
+// RenderTerminal draws the chart as text, one column per day with the value axis on the left
+func (c *Chart) RenderTerminal(w io.Writer, opts TerminalOptions) error {
+	height := opts.Height
+	if height <= 0 {
+		height = 10
+	}
+	top := c.max()
+
+	// Plot every line onto a grid; later lines win where they overlap
+	grid := make([][]rune, height+1)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", len(c.Days)*columnWidth))
+	}
+	for _, line := range c.Lines {
+		glyph := line.Glyph
+		if opts.ASCII {
+			glyph = line.ASCII
+		}
+		for day, v := range line.Values {
+			if day >= len(c.Days) {
+				break
+			}
+			if v < 0 {
+				v = 0
+			}
+			if v > top {
+				v = top
+			}
+			row := height - (v*height+top/2)/top
+			grid[row][day*columnWidth+1] = glyph
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", c.Title, c.Kind)
+
+	labelWidth := len(fmt.Sprint(top))
+	for row, cells := range grid {
+		label := ""
+		if row == 0 || row == height || row == height/2 {
+			label = fmt.Sprint(top - row*top/height)
+		}
+		axis := '│'
+		if opts.ASCII {
+			axis = '|'
+		}
+		fmt.Fprintf(&b, "%*s %c%s\n", labelWidth, label, axis, strings.TrimRight(string(cells), " "))
+	}
+
+	// Day axis with a label at the start, middle and end
+	corner, rule := "└", "─"
+	if opts.ASCII {
+		corner, rule = "+", "-"
+	}
+	fmt.Fprintf(&b, "%*s %s%s\n", labelWidth, "", corner, strings.Repeat(rule, len(c.Days)*columnWidth))
+	labels := []rune(strings.Repeat(" ", len(c.Days)*columnWidth+6))
+	if len(c.Days) > 0 {
+		for _, day := range []int{0, len(c.Days) / 2, len(c.Days) - 1} {
+			copy(labels[day*columnWidth:], []rune(c.Days[day].Format("01-02")))
+		}
+	}
+	fmt.Fprintf(&b, "%*s  %s\n", labelWidth, "", strings.TrimRight(string(labels), " "))
+
+	// Legend
+	for i, line := range c.Lines {
+		glyph := line.Glyph
+		if opts.ASCII {
+			glyph = line.ASCII
+		}
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		fmt.Fprintf(&b, "%c %s", glyph, line.Name)
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
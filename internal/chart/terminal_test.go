@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTerminal(t *testing.T) {
+	s := newTestSprint()
+	c := NewBurnDown(s, s.StartDate.AddDate(0, 0, 2))
+
+	var b strings.Builder
+	if err := c.RenderTerminal(&b, TerminalOptions{Height: 8}); err != nil {
+		t.Fatalf("Failed to render chart: %v", err)
+	}
+	out := b.String()
+
+	// Check the title, the value axis, the day labels and the legend
+	for _, want := range []string{"Implement user authentication (burn-down)", "8 │", "09-22", "09-26", "· ideal", "● actual"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// The first day's actual and ideal values overlap at the top row; actual is drawn last
+	lines := strings.Split(out, "\n")
+	if !strings.Contains(lines[1], "●") {
+		t.Errorf("Expected the top row to contain the first actual point, got %q", lines[1])
+	}
+}
+
+func TestRenderTerminalASCII(t *testing.T) {
+	s := newTestSprint()
+	c := NewBurnUp(s, s.StartDate.AddDate(0, 0, 2))
+
+	var b strings.Builder
+	if err := c.RenderTerminal(&b, TerminalOptions{ASCII: true}); err != nil {
+		t.Fatalf("Failed to render chart: %v", err)
+	}
+	out := b.String()
+
+	// Check that no multi-byte glyphs are used
+	for _, r := range out {
+		if r > 127 {
+			t.Fatalf("Expected ASCII-only output, found %q in:\n%s", r, out)
+		}
+	}
+	if !strings.Contains(out, "- scope") || !strings.Contains(out, "* done") {
+		t.Errorf("Expected ASCII legend, got:\n%s", out)
+	}
+}
+
+func TestRenderTerminalBaselinePoints(t *testing.T) {
+	s := newTestSprint()
+	s.BurnDown = nil
+	s.AddBurnDownPoint(s.StartDate, 10)
+	s.AddBurnDownPoint(s.StartDate.AddDate(0, 0, 1), 7)
+	c := NewBurnUp(s, s.StartDate.AddDate(0, 0, 1))
+
+	// Check that points without a scope render instead of panicking
+	var b strings.Builder
+	if err := c.RenderTerminal(&b, TerminalOptions{}); err != nil {
+		t.Fatalf("Failed to render chart: %v", err)
+	}
+
+	// Check that the unknown scope falls back to the most points left, so 3 are done
+	if scope, done := c.Lines[0].Values, c.Lines[1].Values; scope[1] != 10 || done[0] != 0 || done[1] != 3 {
+		t.Errorf("Expected scope 10 with 0 then 3 done, got %v and %v", scope, done)
+	}
+
+	// Check that out-of-range values are clamped to the plot
+	c.Lines[1].Values[0] = -5
+	b.Reset()
+	if err := c.RenderTerminal(&b, TerminalOptions{}); err != nil {
+		t.Fatalf("Failed to render chart: %v", err)
+	}
+}
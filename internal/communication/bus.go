@@ -16,6 +16,8 @@ const (
 	POAgent  AgentType = "po"
 	DevAgent AgentType = "dev"
 	SMAgent  AgentType = "sm"
+
+	AllAgents AgentType = "all" // Broadcast to every agent
 )
 
 // PriorityLevel represents the priority level of a message
@@ -40,6 +42,14 @@ const (
 	MsgSprintStart       MessageType = "sprint.start"
 	MsgDailyStandup      MessageType = "daily.standup"
 	MsgRetrospective     MessageType = "retrospective"
+	MsgEpicCreated       MessageType = "epic.created"
+	MsgEpicUpdated       MessageType = "epic.updated"
+	MsgReleaseCreated    MessageType = "release.created"
+	MsgReleaseUpdated    MessageType = "release.updated"
+	MsgEstimateRequest   MessageType = "estimate.request"
+	MsgEstimateVote      MessageType = "estimate.vote"
+	MsgEstimateJustify   MessageType = "estimate.justify"
+	MsgEstimateResult    MessageType = "estimate.result"
 	MsgAck               MessageType = "acknowledgment"
 	MsgError             MessageType = "error"
 )
@@ -60,16 +70,16 @@ type AgentMessage struct {
 // that handles publishing and subscribing to messages between agents.
 type AgentBus interface {
 	// Publish sends a message to all subscribers of the specified type
-	publish(message *AgentMessage) error
+	Publish(message *AgentMessage) error
 
 	// Subscribe registers a handler function for messages of a specific type
-	subscribe(messageType MessageType, handler func(*AgentMessage)) error
+	Subscribe(messageType MessageType, handler func(*AgentMessage)) error
 
 	// Unsubscribe removes a handler for messages of a specific type
-	unsubscribe(messageType MessageType, handler func(*AgentMessage)) error
+	Unsubscribe(messageType MessageType, handler func(*AgentMessage)) error
 
 	// Close shuts down the message bus and releases resources
-	close() error
+	Close() error
 }
 
 // communicationError implements the error interface
@@ -108,8 +118,8 @@ func New(socketPath string) (AgentBus, error) {
 	return a, nil
 }
 
-// publish sends a message to all subscribers of the specified type
-func (a *agentBus) publish(message *AgentMessage) error {
+// Publish sends a message to all subscribers of the specified type
+func (a *agentBus) Publish(message *AgentMessage) error {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
 
@@ -128,8 +138,8 @@ func (a *agentBus) publish(message *AgentMessage) error {
 	return err
 }
 
-// subscribe registers a handler function for messages of a specific type
-func (a *agentBus) subscribe(messageType MessageType, handler func(*AgentMessage)) error {
+// Subscribe registers a handler function for messages of a specific type
+func (a *agentBus) Subscribe(messageType MessageType, handler func(*AgentMessage)) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -143,8 +153,8 @@ func (a *agentBus) subscribe(messageType MessageType, handler func(*AgentMessage
 	return nil
 }
 
-// unsubscribe removes a handler for messages of a specific type
-func (a *agentBus) unsubscribe(messageType MessageType, handler func(*AgentMessage)) error {
+// Unsubscribe removes a handler for messages of a specific type
+func (a *agentBus) Unsubscribe(messageType MessageType, handler func(*AgentMessage)) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -165,8 +175,8 @@ func (a *agentBus) unsubscribe(messageType MessageType, handler func(*AgentMessa
 	return nil
 }
 
-// close shuts down the message bus and releases resources
-func (a *agentBus) close() error {
+// Close shuts down the message bus and releases resources
+func (a *agentBus) Close() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
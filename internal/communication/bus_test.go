@@ -28,7 +28,7 @@ func TestNew(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create AgentBus: %v", err)
 	}
-	defer a.close()
+	defer a.Close()
 
 	// Verify the bus was created successfully
 	if a == nil {
@@ -46,7 +46,7 @@ func TestNew(t *testing.T) {
 	}
 
 	// Publish the message
-	err = a.publish(testMessage)
+	err = a.Publish(testMessage)
 	if err != nil {
 		t.Fatalf("Failed to publish message: %v", err)
 	}
@@ -113,7 +113,7 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create AgentBus: %v", err)
 	}
-	defer a.close()
+	defer a.Close()
 
 	// Define a test handler function
 	var receivedMessage *AgentMessage
@@ -130,7 +130,7 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	}
 
 	// Subscribe to messages of type MsgStoryCreated
-	err = a.subscribe(MsgStoryCreated, handler)
+	err = a.Subscribe(MsgStoryCreated, handler)
 	if err != nil {
 		t.Fatalf("Failed to subscribe: %v", err)
 	}
@@ -146,7 +146,7 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	}
 
 	// Publish the message
-	err = a.publish(testMessage)
+	err = a.Publish(testMessage)
 	if err != nil {
 		t.Fatalf("Failed to publish message: %v", err)
 	}
@@ -206,7 +206,7 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	}
 
 	// Unsubscribe from messages of type MsgStoryCreated
-	err = a.unsubscribe(MsgStoryCreated, handler)
+	err = a.Unsubscribe(MsgStoryCreated, handler)
 	if err != nil {
 		t.Fatalf("Failed to unsubscribe: %v", err)
 	}
@@ -222,7 +222,7 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	}
 
 	// Publish the message
-	err = a.publish(testMessage2)
+	err = a.Publish(testMessage2)
 	if err != nil {
 		t.Fatalf("Failed to publish message: %v", err)
 	}
@@ -295,7 +295,7 @@ func TestClose(t *testing.T) {
 	}
 
 	// Close the bus
-	err = a.close()
+	err = a.Close()
 	if err != nil {
 		t.Fatalf("Failed to close AgentBus: %v", err)
 	}
@@ -311,7 +311,7 @@ func TestClose(t *testing.T) {
 	}
 
 	// Try to publish after closing - should fail
-	err = a.publish(testMessage)
+	err = a.Publish(testMessage)
 	if err == nil {
 		t.Error("Expected error when publishing after bus is closed")
 	}
@@ -324,7 +324,7 @@ func TestClose(t *testing.T) {
 	// Try to subscribe after closing - should fail
 	handler := func(*AgentMessage) {}
 
-	err = a.subscribe(MsgStoryCreated, handler)
+	err = a.Subscribe(MsgStoryCreated, handler)
 	if err == nil {
 		t.Error("Expected error when subscribing after bus is closed")
 	}
@@ -335,7 +335,7 @@ func TestClose(t *testing.T) {
 	}
 
 	// Try to unsubscribe after closing - should fail
-	err = a.unsubscribe(MsgStoryCreated, handler)
+	err = a.Unsubscribe(MsgStoryCreated, handler)
 	if err == nil {
 		t.Error("Expected error when unsubscribing after bus is closed")
 	}
@@ -0,0 +1,173 @@
+package communication
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// EpicPayload is the payload of MsgEpicCreated and MsgEpicUpdated messages
+type EpicPayload struct {
+	EpicID      string  `json:"epic_id"`
+	ReleaseID   string  `json:"release_id,omitempty"`
+	Title       string  `json:"title"`
+	Stories     int     `json:"stories"`
+	Points      int     `json:"points"`
+	DonePoints  int     `json:"done_points"`
+	PercentDone float64 `json:"percent_done"`
+}
+
+// ReleasePayload is the payload of MsgReleaseCreated and MsgReleaseUpdated messages
+type ReleasePayload struct {
+	ReleaseID   string    `json:"release_id"`
+	Name        string    `json:"name"`
+	TargetDate  time.Time `json:"target_date"`
+	EpicIDs     []string  `json:"epic_ids"`
+	Points      int       `json:"points"`
+	DonePoints  int       `json:"done_points"`
+	PercentDone float64   `json:"percent_done"`
+}
+
+// NewEpicPayload summarizes an epic with the progress of its stories
+func NewEpicPayload(e *models.Epic, stories []*models.UserStory) EpicPayload {
+	p := e.Progress(stories)
+	return EpicPayload{
+		EpicID:      e.ID,
+		ReleaseID:   e.ReleaseID,
+		Title:       e.Title,
+		Stories:     p.Stories,
+		Points:      p.Points,
+		DonePoints:  p.DonePoints,
+		PercentDone: p.Percent(),
+	}
+}
+
+// NewReleasePayload summarizes a release with its epics and the progress of their stories
+func NewReleasePayload(r *models.Release, epics []*models.Epic, stories []*models.UserStory) ReleasePayload {
+	ids := []string{}
+	for _, e := range r.Epics(epics) {
+		ids = append(ids, e.ID)
+	}
+	p := r.Progress(epics, stories)
+	return ReleasePayload{
+		ReleaseID:   r.ID,
+		Name:        r.Name,
+		TargetDate:  r.TargetDate,
+		EpicIDs:     ids,
+		Points:      p.Points,
+		DonePoints:  p.DonePoints,
+		PercentDone: p.Percent(),
+	}
+}
+
+// PublishEpic broadcasts an epic from the PO agent, as MsgEpicCreated when created is set and MsgEpicUpdated otherwise
+func PublishEpic(bus AgentBus, e *models.Epic, stories []*models.UserStory, created bool) error {
+	messageType := MsgEpicUpdated
+	if created {
+		messageType = MsgEpicCreated
+	}
+	return publish(bus, messageType, NewEpicPayload(e, stories))
+}
+
+// PublishRelease broadcasts a release from the PO agent, as MsgReleaseCreated when created is set and MsgReleaseUpdated otherwise
+func PublishRelease(bus AgentBus, r *models.Release, epics []*models.Epic, stories []*models.UserStory, created bool) error {
+	messageType := MsgReleaseUpdated
+	if created {
+		messageType = MsgReleaseCreated
+	}
+	return publish(bus, messageType, NewReleasePayload(r, epics, stories))
+}
+
+// publish broadcasts a payload from the PO agent
+func publish(bus AgentBus, messageType MessageType, payload any) error {
+	msg, err := NewMessage(POAgent, AllAgents, messageType, MediumPriority, payload)
+	if err != nil {
+		return err
+	}
+	return bus.Publish(msg)
+}
+
+// ProgressPayload is the payload of MsgProgressUpdate messages, one member's daily update
+type ProgressPayload struct {
+	MemberID  string   `json:"member_id"`
+	TaskID    string   `json:"task_id,omitempty"`
+	Yesterday string   `json:"yesterday"`
+	Today     string   `json:"today"`
+	Blockers  []string `json:"blockers,omitempty"`
+}
+
+// EstimateRequestPayload is the payload of MsgEstimateRequest messages, opening a round of planning poker
+type EstimateRequestPayload struct {
+	SessionID      string            `json:"session_id"`
+	StoryID        string            `json:"story_id"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	Criteria       []string          `json:"criteria"`
+	Round          int               `json:"round"`
+	Scale          []int             `json:"scale"`
+	Estimators     []string          `json:"estimators"`
+	Justifications map[string]string `json:"justifications,omitempty"` // Outliers' reasons from the previous round
+}
+
+// EstimateVotePayload is the payload of MsgEstimateVote messages, one estimator's card or justification
+type EstimateVotePayload struct {
+	SessionID     string  `json:"session_id"`
+	Round         int     `json:"round"`
+	EstimatorID   string  `json:"estimator_id"`
+	Points        int     `json:"points"`
+	Optimistic    float64 `json:"optimistic,omitempty"` // Hours
+	Likely        float64 `json:"likely,omitempty"`
+	Pessimistic   float64 `json:"pessimistic,omitempty"`
+	Justification string  `json:"justification,omitempty"`
+}
+
+// EstimateJustifyPayload is the payload of MsgEstimateJustify messages, asking outliers to explain their votes
+type EstimateJustifyPayload struct {
+	SessionID string         `json:"session_id"`
+	StoryID   string         `json:"story_id"`
+	Round     int            `json:"round"`
+	Outliers  []string       `json:"outliers"`
+	Votes     map[string]int `json:"votes"` // Revealed cards by estimator
+}
+
+// EstimateResultPayload is the payload of MsgEstimateResult messages, closing a session
+type EstimateResultPayload struct {
+	SessionID  string  `json:"session_id"`
+	StoryID    string  `json:"story_id"`
+	Points     int     `json:"points"`
+	Confidence float64 `json:"confidence"`
+	Consensus  bool    `json:"consensus"`
+	Rounds     int     `json:"rounds"`
+	Hours      float64 `json:"hours,omitempty"` // PERT expected hours
+}
+
+// NewMessage creates a message with a fresh ID and timestamp and the payload encoded as JSON
+func NewMessage(from, to AgentType, messageType MessageType, priority PriorityLevel, payload any) (*AgentMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AgentMessage{
+		ID:        generateIDMsg(),
+		Timestamp: time.Now(),
+		From:      from,
+		To:        to,
+		Type:      messageType,
+		Priority:  priority,
+		Payload:   data,
+	}, nil
+}
+
+// generateIDMsg generates a unique ID for a message
+func generateIDMsg() string {
+	return "msg-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// DecodePayload unmarshals the message payload into v
+func (m *AgentMessage) DecodePayload(v any) error {
+	return json.Unmarshal(m.Payload, v)
+}
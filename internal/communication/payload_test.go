@@ -0,0 +1,129 @@
+package communication
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+func TestNewMessage(t *testing.T) {
+	payload := EpicPayload{EpicID: "epic-1", Title: "User accounts", Points: 8, DonePoints: 5, PercentDone: 62.5}
+
+	msg, err := NewMessage(POAgent, DevAgent, MsgEpicUpdated, MediumPriority, payload)
+	if err != nil {
+		t.Fatalf("Failed to create message: %v", err)
+	}
+
+	// Check the envelope fields
+	if msg.ID == "" || msg.Timestamp.IsZero() {
+		t.Errorf("Expected ID and Timestamp to be set, got %q and %v", msg.ID, msg.Timestamp)
+	}
+	if msg.From != POAgent || msg.To != DevAgent || msg.Type != MsgEpicUpdated || msg.Priority != MediumPriority {
+		t.Errorf("Expected envelope po -> dev epic.updated, got %+v", msg)
+	}
+
+	// Check that the payload round-trips
+	var decoded EpicPayload
+	if err := msg.DecodePayload(&decoded); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if decoded.EpicID != "epic-1" || decoded.DonePoints != 5 {
+		t.Errorf("Expected decoded payload to match, got %+v", decoded)
+	}
+}
+
+func TestNewMessageRejectsUnencodablePayload(t *testing.T) {
+	_, err := NewMessage(POAgent, DevAgent, MsgEpicCreated, LowPriority, func() {})
+	if err == nil {
+		t.Error("Expected error for a payload that cannot be encoded")
+	}
+}
+
+// newEchoBus connects a bus to a socket that sends every message straight back
+func newEchoBus(t *testing.T) AgentBus {
+	path := filepath.Join(t.TempDir(), "bus.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+
+	bus, err := New(path)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { bus.Close() })
+	return bus
+}
+
+// receive waits for the next message handed to a subscription
+func receive(t *testing.T, received chan *AgentMessage) *AgentMessage {
+	select {
+	case msg := <-received:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a message on the bus")
+		return nil
+	}
+}
+
+func TestPublishEpicAndRelease(t *testing.T) {
+	bus := newEchoBus(t)
+	received := make(chan *AgentMessage, 1)
+	handler := func(msg *AgentMessage) { received <- msg }
+	for _, messageType := range []MessageType{MsgEpicUpdated, MsgReleaseCreated} {
+		if err := bus.Subscribe(messageType, handler); err != nil {
+			t.Fatalf("Failed to subscribe: %v", err)
+		}
+	}
+
+	release := models.NewRelease("1.0", "First launch", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC))
+	epic := models.NewEpic("User accounts", "")
+	epic.SetRelease(release.ID)
+	done := models.NewUserStory("Sign in", "")
+	done.SetEpic(epic.ID)
+	done.SetEstimate(3)
+	done.Status = models.StoryDone
+	open := models.NewUserStory("Reset password", "")
+	open.SetEpic(epic.ID)
+	open.SetEstimate(5)
+	stories := []*models.UserStory{done, open}
+
+	// Check that an updated epic arrives with its progress
+	if err := PublishEpic(bus, epic, stories, false); err != nil {
+		t.Fatalf("Failed to publish epic: %v", err)
+	}
+	msg := receive(t, received)
+	var ep EpicPayload
+	if err := msg.DecodePayload(&ep); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if msg.Type != MsgEpicUpdated || msg.To != AllAgents || ep.EpicID != epic.ID || ep.ReleaseID != release.ID || ep.Stories != 2 || ep.Points != 8 || ep.DonePoints != 3 {
+		t.Errorf("Expected epic.updated with 3 of 8 points done, got %s %+v", msg.Type, ep)
+	}
+
+	// Check that a created release arrives with its epics
+	if err := PublishRelease(bus, release, []*models.Epic{epic}, stories, true); err != nil {
+		t.Fatalf("Failed to publish release: %v", err)
+	}
+	msg = receive(t, received)
+	var rp ReleasePayload
+	if err := msg.DecodePayload(&rp); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if msg.Type != MsgReleaseCreated || rp.ReleaseID != release.ID || len(rp.EpicIDs) != 1 || rp.EpicIDs[0] != epic.ID || rp.PercentDone != 37.5 {
+		t.Errorf("Expected release.created with one epic at 37.5%%, got %s %+v", msg.Type, rp)
+	}
+}
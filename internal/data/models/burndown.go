@@ -0,0 +1,177 @@
+// Burn-down derivation from committed story points and completions
+package models
+
+import (
+	"time"
+)
+
+// PointsLookup returns the estimated story points of a story by ID
+type PointsLookup func(storyID string) int
+
+// StoryPoints creates a PointsLookup reading the current estimates of the given stories;
+// unknown or unestimated stories count as 0
+func StoryPoints(stories ...*UserStory) PointsLookup {
+	byID := make(map[string]*UserStory, len(stories))
+	for _, us := range stories {
+		byID[us.ID] = us
+	}
+	return func(storyID string) int {
+		us, ok := byID[storyID]
+		if !ok || us.Estimate == nil {
+			return 0
+		}
+		return us.Estimate.Points
+	}
+}
+
+// TrackPoints attaches a points lookup so that committing, removing and completing
+// stories automatically records the day's burn-down snapshot
+func (s *Sprint) TrackPoints(points PointsLookup) {
+	s.points = points
+}
+
+// refreshBurnDown records today's snapshot if a points lookup is attached, as an event
+// derived from the change that triggered it
+func (s *Sprint) refreshBurnDown() {
+	if s.points != nil {
+		s.recordBurnDown(time.Now(), s.points, EventSprintBurnDownRefreshed)
+	}
+}
+
+// RecordBurnDown computes scope and remaining points from the committed and completed
+// stories and stores them as the snapshot for the given day, replacing any earlier one
+func (s *Sprint) RecordBurnDown(date time.Time, points PointsLookup) BurnDownPoint {
+	return s.recordBurnDown(date, points, EventSprintBurnDownRecorded)
+}
+
+// recordBurnDown stores the day's snapshot and emits it as the given event type
+func (s *Sprint) recordBurnDown(date time.Time, points PointsLookup, eventType EventType) BurnDownPoint {
+	point := BurnDownPoint{Date: truncateDay(date)}
+	for _, id := range s.Committed {
+		p := points(id)
+		point.Scope += p
+		if !contains(s.Completed, id) {
+			point.Left += p
+		}
+	}
+
+	s.setBurnDownPoint(point)
+	emit(s.recorder, KindSprint, s.ID, eventType, point)
+
+	return point
+}
+
+// setBurnDownPoint replaces the snapshot of the same day or inserts the point in date order
+func (s *Sprint) setBurnDownPoint(point BurnDownPoint) {
+	for i, p := range s.BurnDown {
+		if truncateDay(p.Date).Equal(point.Date) {
+			s.BurnDown[i] = point
+			return
+		}
+		if p.Date.After(point.Date) {
+			s.BurnDown = append(s.BurnDown[:i], append([]BurnDownPoint{point}, s.BurnDown[i:]...)...)
+			return
+		}
+	}
+	s.BurnDown = append(s.BurnDown, point)
+}
+
+// CommittedScope sums the committed story points through the attached points lookup,
+// false when no lookup is attached
+func (s *Sprint) CommittedScope() (int, bool) {
+	if s.points == nil {
+		return 0, false
+	}
+	total := 0
+	for _, id := range s.Committed {
+		total += s.points(id)
+	}
+	return total, true
+}
+
+// pointScope returns the snapshot's scope; snapshots without one, as recorded by
+// AddBurnDownPoint, count their remaining points
+func pointScope(p BurnDownPoint) int {
+	if p.Scope == 0 {
+		return p.Left
+	}
+	return p.Scope
+}
+
+// startScope returns the scope as of StartDate: the last snapshot taken by then, else the
+// committed points when a lookup is attached, else the first snapshot taken later
+func (s *Sprint) startScope() int {
+	start := truncateDay(s.StartDate)
+	scope, found := 0, false
+	for _, p := range s.BurnDown {
+		if truncateDay(p.Date).After(start) {
+			break
+		}
+		scope, found = pointScope(p), true
+	}
+	if found {
+		return scope
+	}
+	if committed, ok := s.CommittedScope(); ok {
+		return committed
+	}
+	if len(s.BurnDown) > 0 {
+		return pointScope(s.BurnDown[0])
+	}
+	return 0
+}
+
+// DailyBurnDown returns one point per day from StartDate to the earlier of until and EndDate,
+// carrying the last recorded snapshot forward over days without changes. Days before the
+// first snapshot carry the scope as of StartDate with nothing done.
+func (s *Sprint) DailyBurnDown(until time.Time) []BurnDownPoint {
+	start := truncateDay(s.StartDate)
+	end := truncateDay(s.EndDate)
+	if until = truncateDay(until); until.Before(end) {
+		end = until
+	}
+
+	var daily []BurnDownPoint
+	scope := s.startScope()
+	last := BurnDownPoint{Left: scope, Scope: scope}
+	next := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		for next < len(s.BurnDown) && !truncateDay(s.BurnDown[next].Date).After(day) {
+			last = s.BurnDown[next]
+			next++
+		}
+		point := last
+		point.Date = day
+		daily = append(daily, point)
+	}
+
+	return daily
+}
+
+// IdealLine returns the ideal burn-down from the scope as of StartDate to zero on EndDate
+func (s *Sprint) IdealLine() []BurnDownPoint {
+	start := truncateDay(s.StartDate)
+	end := truncateDay(s.EndDate)
+	if end.Before(start) {
+		return nil
+	}
+
+	scope := s.startScope()
+
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+	ideal := make([]BurnDownPoint, 0, days+1)
+	for i := 0; i <= days; i++ {
+		left := scope
+		if days > 0 {
+			left = scope - scope*i/days
+		}
+		ideal = append(ideal, BurnDownPoint{Date: start.AddDate(0, 0, i), Left: left, Scope: scope})
+	}
+
+	return ideal
+}
+
+// truncateDay returns midnight of the date in its own location
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// newEstimatedStory creates a story with the given ID and points
+func newEstimatedStory(id string, points int) *UserStory {
+	us := NewUserStory("Story "+id, "")
+	us.ID = id
+	us.SetEstimate(points)
+	return us
+}
+
+func TestSprintRecordBurnDown(t *testing.T) {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	s := NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+	s.AddCommittedStory("story-1")
+	s.AddCommittedStory("story-2")
+	points := StoryPoints(newEstimatedStory("story-1", 5), newEstimatedStory("story-2", 3))
+
+	// Check that scope and remaining points are derived from the stories
+	point := s.RecordBurnDown(start.Add(9*time.Hour), points)
+	if point.Scope != 8 || point.Left != 8 {
+		t.Errorf("Expected scope 8 and 8 left, got %d and %d", point.Scope, point.Left)
+	}
+	if !point.Date.Equal(start) {
+		t.Errorf("Expected point date to be truncated to %v, got %v", start, point.Date)
+	}
+
+	// A later snapshot on the same day replaces the earlier one
+	s.Completed = append(s.Completed, "story-2")
+	s.RecordBurnDown(start.Add(17*time.Hour), points)
+	if len(s.BurnDown) != 1 {
+		t.Fatalf("Expected 1 burn-down point, got %d", len(s.BurnDown))
+	}
+	if s.BurnDown[0].Left != 5 {
+		t.Errorf("Expected 5 left, got %d", s.BurnDown[0].Left)
+	}
+
+	// Earlier days are inserted in date order
+	s.RecordBurnDown(start.AddDate(0, 0, -1), points)
+	if len(s.BurnDown) != 2 || !s.BurnDown[0].Date.Before(s.BurnDown[1].Date) {
+		t.Errorf("Expected burn-down points in date order, got %v", s.BurnDown)
+	}
+}
+
+func TestSprintTrackPoints(t *testing.T) {
+	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+	story1 := newEstimatedStory("story-1", 5)
+	story2 := newEstimatedStory("story-2", 3)
+	s.TrackPoints(StoryPoints(story1, story2))
+
+	// Scope changes and completions refresh today's snapshot automatically
+	s.AddCommittedStory("story-1")
+	s.AddCommittedStory("story-2")
+	s.AddCompletedStory("story-1")
+	if len(s.BurnDown) != 1 {
+		t.Fatalf("Expected 1 burn-down point for today, got %d", len(s.BurnDown))
+	}
+	if s.BurnDown[0].Scope != 8 || s.BurnDown[0].Left != 3 {
+		t.Errorf("Expected scope 8 and 3 left, got %d and %d", s.BurnDown[0].Scope, s.BurnDown[0].Left)
+	}
+
+	// Removing a story mid-sprint shrinks the scope
+	s.RemoveCommittedStory("story-2")
+	if s.BurnDown[0].Scope != 5 || s.BurnDown[0].Left != 0 {
+		t.Errorf("Expected scope 5 and 0 left, got %d and %d", s.BurnDown[0].Scope, s.BurnDown[0].Left)
+	}
+}
+
+func TestSprintDailyBurnDown(t *testing.T) {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	s := NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+	s.AddBurnDownPoint(start, 8)
+	s.AddBurnDownPoint(start.AddDate(0, 0, 2), 5)
+
+	// Days without snapshots carry the previous values forward
+	daily := s.DailyBurnDown(start.AddDate(0, 0, 3))
+	expected := []int{8, 8, 5, 5}
+	if len(daily) != len(expected) {
+		t.Fatalf("Expected %d daily points, got %d", len(expected), len(daily))
+	}
+	for i, point := range daily {
+		if point.Left != expected[i] {
+			t.Errorf("Expected day %d to have %d left, got %d", i, expected[i], point.Left)
+		}
+		if !point.Date.Equal(start.AddDate(0, 0, i)) {
+			t.Errorf("Expected day %d to be %v, got %v", i, start.AddDate(0, 0, i), point.Date)
+		}
+	}
+
+	// The series never extends past EndDate
+	if daily := s.DailyBurnDown(start.AddDate(0, 0, 30)); len(daily) != 5 {
+		t.Errorf("Expected 5 daily points, got %d", len(daily))
+	}
+}
+
+func TestSprintIdealLine(t *testing.T) {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	s := NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+	s.AddCommittedStory("story-1")
+	s.RecordBurnDown(start, StoryPoints(newEstimatedStory("story-1", 8)))
+
+	// Check that the ideal line runs linearly from the initial scope to zero
+	ideal := s.IdealLine()
+	expected := []int{8, 6, 4, 2, 0}
+	if len(ideal) != len(expected) {
+		t.Fatalf("Expected %d ideal points, got %d", len(expected), len(ideal))
+	}
+	for i, point := range ideal {
+		if point.Left != expected[i] {
+			t.Errorf("Expected ideal day %d to have %d left, got %d", i, expected[i], point.Left)
+		}
+	}
+}
+
+func TestSprintLateFirstSnapshot(t *testing.T) {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	s := NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+	s.TrackPoints(StoryPoints(newEstimatedStory("story-1", 8), newEstimatedStory("story-2", 4)))
+	s.Committed = []string{"story-1", "story-2"}
+	s.Completed = []string{"story-2"}
+
+	// Check that the first snapshot, taken on day 2 after story-1 was re-estimated, does not set the ideal line
+	s.RecordBurnDown(start.AddDate(0, 0, 2), StoryPoints(newEstimatedStory("story-1", 10), newEstimatedStory("story-2", 4)))
+	if ideal := s.IdealLine(); ideal[0].Left != 12 {
+		t.Errorf("Expected the ideal line to start from the committed 12 points, got %d", ideal[0].Left)
+	}
+
+	// Check that days before the first snapshot carry the starting scope, not zero
+	daily := s.DailyBurnDown(start.AddDate(0, 0, 2))
+	if daily[0].Left != 12 || daily[1].Left != 12 || daily[2].Left != 10 {
+		t.Errorf("Expected 12, 12 and 10 left, got %v", daily)
+	}
+
+	// Check that without a lookup the first snapshot is the best estimate of the scope
+	s = NewSprint("Implement user authentication", start, start.AddDate(0, 0, 4))
+	s.AddBurnDownPoint(start.AddDate(0, 0, 1), 6)
+	if daily := s.DailyBurnDown(start.AddDate(0, 0, 1)); daily[0].Left != 6 || s.IdealLine()[0].Left != 6 {
+		t.Errorf("Expected 6 left on the first day, got %v", daily)
+	}
+}
+
+func TestSprintApplyBurnDownRecorded(t *testing.T) {
+	r := &recorderStub{}
+	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+	s.Track(r)
+	s.TrackPoints(StoryPoints(newEstimatedStory("story-1", 5)))
+	s.AddCommittedStory("story-1")
+	s.AddCompletedStory("story-1")
+
+	replayed := &Sprint{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+
+	// Check that replayed snapshots replace each other like the originals
+	if len(replayed.BurnDown) != 1 || replayed.BurnDown[0].Left != 0 || replayed.BurnDown[0].Scope != 5 {
+		t.Errorf("Expected one snapshot with scope 5 and 0 left, got %v", replayed.BurnDown)
+	}
+}
@@ -0,0 +1,413 @@
+// Criterion parsing and validation for Given/When/Then acceptance criteria
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrMalformedCriterion is returned when an acceptance criterion is not valid Given/When/Then
+var ErrMalformedCriterion = modelError("malformed acceptance criterion")
+
+// Keyword represents a Gherkin step keyword
+type Keyword string
+
+const (
+	KeywordGiven Keyword = "Given"
+	KeywordWhen  Keyword = "When"
+	KeywordThen  Keyword = "Then"
+	KeywordAnd   Keyword = "And"
+	KeywordBut   Keyword = "But"
+)
+
+// Languages of the Gherkin keywords understood by the parser
+const (
+	LanguageEnglish = "en"
+	LanguageChinese = "zh-CN"
+)
+
+// Step is one Given/When/Then line of a scenario
+type Step struct {
+	Keyword Keyword `json:"keyword"`
+	Text    string  `json:"text"`
+}
+
+// Examples is the table of values substituted into a scenario outline
+type Examples struct {
+	Header []string   `json:"header"`
+	Rows   [][]string `json:"rows"`
+}
+
+// Scenario is a structured acceptance criterion
+type Scenario struct {
+	Language string    `json:"language"`
+	Name     string    `json:"name,omitempty"`
+	Outline  bool      `json:"outline,omitempty"`
+	Steps    []Step    `json:"steps"`
+	Examples *Examples `json:"examples,omitempty"`
+}
+
+// stepWord maps a written keyword to its meaning and language
+type stepWord struct {
+	word     string
+	keyword  Keyword
+	language string
+}
+
+// stepWords lists the accepted keywords; the first word per keyword and language is used for output
+var stepWords = []stepWord{
+	{"Given", KeywordGiven, LanguageEnglish},
+	{"When", KeywordWhen, LanguageEnglish},
+	{"Then", KeywordThen, LanguageEnglish},
+	{"And", KeywordAnd, LanguageEnglish},
+	{"But", KeywordBut, LanguageEnglish},
+	{"假如", KeywordGiven, LanguageChinese},
+	{"给定", KeywordGiven, LanguageChinese},
+	{"假设", KeywordGiven, LanguageChinese},
+	{"假定", KeywordGiven, LanguageChinese},
+	{"当", KeywordWhen, LanguageChinese},
+	{"那么", KeywordThen, LanguageChinese},
+	{"而且", KeywordAnd, LanguageChinese},
+	{"并且", KeywordAnd, LanguageChinese},
+	{"同时", KeywordAnd, LanguageChinese},
+	{"但是", KeywordBut, LanguageChinese},
+}
+
+// compounds lists, per Chinese keyword, the characters that extend it into another word,
+// such as 当前 or 当然, so text starting with those words is not taken for a step
+var compounds = map[string]string{
+	"当": "前时然天地中初今日月年下场面即代选作成做真局事心",
+}
+
+// stepSeparators may follow a Chinese keyword before the step text
+const stepSeparators = " \t，,：:、"
+
+// headerWords lists the scenario and examples headers per language, outline headers first
+var headerWords = map[string]struct {
+	outline, scenario, examples []string
+}{
+	LanguageEnglish: {[]string{"Scenario Outline", "Scenario Template"}, []string{"Scenario", "Example"}, []string{"Examples", "Scenarios"}},
+	LanguageChinese: {[]string{"场景大纲", "剧本大纲"}, []string{"场景", "剧本"}, []string{"例子"}},
+}
+
+// placeholderPattern matches <name> placeholders of scenario outlines
+var placeholderPattern = regexp.MustCompile(`<([^<>]+)>`)
+
+// ParseScenario parses an acceptance criterion written as Given/When/Then, either one step per line
+// with optional Scenario and Examples sections, or on a single line with comma separated steps
+// such as "给定[条件]，当[操作]，那么[结果]". The result is validated.
+func ParseScenario(text string) (Scenario, error) {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "@") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 1 {
+		if _, _, ok := matchHeader(lines[0]); !ok {
+			lines = splitSteps(lines[0])
+		}
+	}
+
+	s := Scenario{Language: LanguageEnglish}
+	inExamples := false
+	for i, line := range lines {
+		if kind, rest, ok := matchHeader(line); ok {
+			switch kind {
+			case "examples":
+				if s.Examples == nil {
+					s.Examples = &Examples{}
+				}
+				inExamples = true
+			default:
+				if i > 0 {
+					return s, fmt.Errorf("%w: unexpected %q", ErrMalformedCriterion, line)
+				}
+				s.Name = rest
+				s.Outline = kind == "outline"
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "|") {
+			if !inExamples {
+				return s, fmt.Errorf("%w: table outside Examples: %q", ErrMalformedCriterion, line)
+			}
+			cells := parseRow(line)
+			if s.Examples.Header == nil {
+				s.Examples.Header = cells
+			} else {
+				s.Examples.Rows = append(s.Examples.Rows, cells)
+			}
+			continue
+		}
+
+		step, language, ok := matchStep(line)
+		if !ok || inExamples {
+			return s, fmt.Errorf("%w: %q is not a Given/When/Then step", ErrMalformedCriterion, line)
+		}
+		if len(s.Steps) == 0 {
+			s.Language = language
+		}
+		s.Steps = append(s.Steps, step)
+	}
+
+	return s, s.Validate()
+}
+
+// splitSteps splits a single-line criterion at commas or semicolons followed by a step keyword
+func splitSteps(line string) []string {
+	parts := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == '，' || r == ';' || r == '；'
+	})
+
+	var steps []string
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if _, _, ok := matchStep(part); ok || len(steps) == 0 {
+			steps = append(steps, part)
+		} else {
+			steps[len(steps)-1] += ", " + part
+		}
+	}
+	return steps
+}
+
+// matchStep recognizes a step keyword at the start of the line. English keywords must be followed
+// by a space; Chinese ones by a separator or a character that does not make them part of a longer word.
+func matchStep(line string) (Step, string, bool) {
+	for _, w := range stepWords {
+		var rest string
+		if w.language == LanguageEnglish {
+			if len(line) <= len(w.word) || !strings.EqualFold(line[:len(w.word)], w.word) || line[len(w.word)] != ' ' {
+				continue
+			}
+			rest = line[len(w.word):]
+		} else {
+			if !strings.HasPrefix(line, w.word) {
+				continue
+			}
+			rest = line[len(w.word):]
+			next, _ := utf8.DecodeRuneInString(rest)
+			if rest == "" || strings.ContainsRune(compounds[w.word], next) {
+				continue
+			}
+			rest = strings.TrimLeft(rest, stepSeparators)
+		}
+		rest = strings.TrimRight(strings.TrimSpace(rest), "。.")
+		return Step{Keyword: w.keyword, Text: rest}, w.language, true
+	}
+	return Step{}, "", false
+}
+
+// matchHeader recognizes a scenario, outline or examples header and returns the text after the colon
+func matchHeader(line string) (string, string, bool) {
+	for _, language := range []string{LanguageEnglish, LanguageChinese} {
+		headers := headerWords[language]
+		for _, group := range []struct {
+			kind  string
+			words []string
+		}{{"outline", headers.outline}, {"scenario", headers.scenario}, {"examples", headers.examples}} {
+			for _, word := range group.words {
+				if !strings.HasPrefix(line, word) {
+					continue
+				}
+				rest := strings.TrimSpace(line[len(word):])
+				if strings.HasPrefix(rest, ":") {
+					return group.kind, strings.TrimSpace(rest[1:]), true
+				}
+				if strings.HasPrefix(rest, "：") {
+					return group.kind, strings.TrimSpace(rest[len("："):]), true
+				}
+			}
+		}
+	}
+	return "", "", false
+}
+
+// parseRow splits a | separated table row into trimmed cells
+func parseRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// stepPhase orders the primary keywords
+var stepPhase = map[Keyword]int{KeywordGiven: 0, KeywordWhen: 1, KeywordThen: 2}
+
+// Validate checks that the steps follow Given, When, Then order with at least a When and a Then,
+// and that outlines define every placeholder in their examples
+func (s Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("%w: no steps", ErrMalformedCriterion)
+	}
+
+	phase := -1
+	seen := make(map[Keyword]bool)
+	for _, step := range s.Steps {
+		if strings.TrimSpace(step.Text) == "" {
+			return fmt.Errorf("%w: empty %s step", ErrMalformedCriterion, step.Keyword)
+		}
+		if step.Keyword == KeywordAnd || step.Keyword == KeywordBut {
+			if phase < 0 {
+				return fmt.Errorf("%w: %s cannot start a scenario", ErrMalformedCriterion, step.Keyword)
+			}
+			continue
+		}
+		p, ok := stepPhase[step.Keyword]
+		if !ok {
+			return fmt.Errorf("%w: unknown keyword %q", ErrMalformedCriterion, step.Keyword)
+		}
+		if p < phase {
+			return fmt.Errorf("%w: %s after %s", ErrMalformedCriterion, step.Keyword, phaseKeyword(phase))
+		}
+		phase = p
+		seen[step.Keyword] = true
+	}
+	if !seen[KeywordWhen] {
+		return fmt.Errorf("%w: missing When", ErrMalformedCriterion)
+	}
+	if !seen[KeywordThen] {
+		return fmt.Errorf("%w: missing Then", ErrMalformedCriterion)
+	}
+
+	if !s.Outline {
+		if s.Examples != nil {
+			return fmt.Errorf("%w: Examples outside a scenario outline", ErrMalformedCriterion)
+		}
+		return nil
+	}
+	if s.Examples == nil || len(s.Examples.Header) == 0 || len(s.Examples.Rows) == 0 {
+		return fmt.Errorf("%w: scenario outline without examples", ErrMalformedCriterion)
+	}
+	for i, row := range s.Examples.Rows {
+		if len(row) != len(s.Examples.Header) {
+			return fmt.Errorf("%w: examples row %d has %d cells, expected %d", ErrMalformedCriterion, i+1, len(row), len(s.Examples.Header))
+		}
+	}
+	for _, name := range s.Placeholders() {
+		if !contains(s.Examples.Header, name) {
+			return fmt.Errorf("%w: placeholder <%s> missing from examples", ErrMalformedCriterion, name)
+		}
+	}
+	return nil
+}
+
+// phaseKeyword returns the keyword of a phase
+func phaseKeyword(phase int) Keyword {
+	for keyword, p := range stepPhase {
+		if p == phase {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// Placeholders returns the distinct <name> placeholders used in the steps, in order of appearance
+func (s Scenario) Placeholders() []string {
+	var names []string
+	for _, step := range s.Steps {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(step.Text, -1) {
+			if !contains(names, m[1]) {
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// word returns the keyword as written in the scenario's language
+func (s Scenario) word(keyword Keyword) string {
+	language := s.Language
+	if language == "" {
+		language = LanguageEnglish
+	}
+	for _, w := range stepWords {
+		if w.keyword == keyword && w.language == language {
+			return w.word
+		}
+	}
+	return string(keyword)
+}
+
+// String renders the scenario in Gherkin, in its own language
+func (s Scenario) String() string {
+	headers := headerWords[LanguageEnglish]
+	separator := " "
+	if s.Language == LanguageChinese {
+		headers = headerWords[LanguageChinese]
+		separator = ""
+	}
+
+	var b strings.Builder
+	indent := ""
+	if s.Name != "" || s.Outline {
+		header := headers.scenario[0]
+		if s.Outline {
+			header = headers.outline[0]
+		}
+		fmt.Fprintf(&b, "%s: %s\n", header, s.Name)
+		indent = "  "
+	}
+	for _, step := range s.Steps {
+		fmt.Fprintf(&b, "%s%s%s%s\n", indent, s.word(step.Keyword), separator, step.Text)
+	}
+	if s.Examples != nil {
+		fmt.Fprintf(&b, "\n%s%s:\n", indent, headers.examples[0])
+		writeTable(&b, indent+"  ", append([][]string{s.Examples.Header}, s.Examples.Rows...))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeTable writes rows as a | separated table with aligned columns
+func writeTable(b *strings.Builder, indent string, rows [][]string) {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for _, row := range rows {
+		b.WriteString(indent + "|")
+		for i, cell := range row {
+			fmt.Fprintf(b, " %s%s |", cell, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// AddScenario validates the scenario and adds it as an acceptance criterion.
+// The story keeps only the scenario's Gherkin text, Scenarios parses it back.
+func (us *UserStory) AddScenario(s Scenario) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	us.AddAcceptanceCriterion(s.String())
+	return nil
+}
+
+// Scenarios parses every acceptance criterion; errs holds one entry per criterion, nil when valid.
+// Scenarios are not stored on the story: criteria stay plain text, so free-form criteria remain
+// valid and every read parses them again.
+func (us *UserStory) Scenarios() (scenarios []Scenario, errs []error) {
+	for _, criterion := range us.AcceptanceCriteria {
+		s, err := ParseScenario(criterion)
+		scenarios = append(scenarios, s)
+		errs = append(errs, err)
+	}
+	return scenarios, errs
+}
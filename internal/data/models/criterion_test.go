@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseScenario(t *testing.T) {
+	text := `Scenario: Successful login
+  Given a registered user
+  And the login page is open
+  When they submit valid credentials
+  Then they see the dashboard`
+
+	s, err := ParseScenario(text)
+	if err != nil {
+		t.Fatalf("Failed to parse scenario: %v", err)
+	}
+
+	// Check the header and steps
+	if s.Name != "Successful login" || s.Outline || s.Language != LanguageEnglish {
+		t.Errorf("Expected English scenario 'Successful login', got %+v", s)
+	}
+	if len(s.Steps) != 4 || s.Steps[1].Keyword != KeywordAnd || s.Steps[3].Text != "they see the dashboard" {
+		t.Errorf("Expected 4 steps, got %+v", s.Steps)
+	}
+
+	// Rendering gives back the same text
+	if s.String() != text {
+		t.Errorf("Expected round trip, got %q", s.String())
+	}
+}
+
+func TestParseScenarioSingleLine(t *testing.T) {
+	// The PO template: 给定[条件]，当[操作]，那么[结果]
+	s, err := ParseScenario("给定用户已登录，当用户点击退出，那么跳转到登录页。")
+	if err != nil {
+		t.Fatalf("Failed to parse scenario: %v", err)
+	}
+	if s.Language != LanguageChinese || len(s.Steps) != 3 {
+		t.Fatalf("Expected 3 Chinese steps, got %+v", s)
+	}
+	if s.Steps[0].Keyword != KeywordGiven || s.Steps[0].Text != "用户已登录" || s.Steps[2].Text != "跳转到登录页" {
+		t.Errorf("Expected Given 用户已登录 ... Then 跳转到登录页, got %+v", s.Steps)
+	}
+
+	// Words starting with a keyword's character are not steps
+	s, err = ParseScenario("给定用户已登录，当 用户点击退出，那么跳转到登录页，当前页面关闭")
+	if err != nil {
+		t.Fatalf("Failed to parse scenario: %v", err)
+	}
+	if len(s.Steps) != 3 || s.Steps[1].Text != "用户点击退出" || s.Steps[2].Text != "跳转到登录页, 当前页面关闭" {
+		t.Errorf("Expected 当前 to stay in the Then step, got %+v", s.Steps)
+	}
+	if _, err := ParseScenario("给定用户已登录\n当前页面关闭\n那么跳转到登录页"); !errors.Is(err, ErrMalformedCriterion) {
+		t.Errorf("Expected error %v for 当前 as a step, got %v", ErrMalformedCriterion, err)
+	}
+
+	// English commas inside a step are kept
+	s, err = ParseScenario("Given a cart with apples, pears and plums, when I check out, then I pay for three items")
+	if err != nil {
+		t.Fatalf("Failed to parse scenario: %v", err)
+	}
+	if len(s.Steps) != 3 || s.Steps[0].Text != "a cart with apples, pears and plums" {
+		t.Errorf("Expected the Given step to keep its comma, got %+v", s.Steps)
+	}
+}
+
+func TestParseScenarioOutline(t *testing.T) {
+	text := `Scenario Outline: Password rules
+  Given the password <password>
+  When the user registers
+  Then the result is <result>
+
+  Examples:
+    | password | result   |
+    | abc      | rejected |
+    | Abc12345 | accepted |`
+
+	s, err := ParseScenario(text)
+	if err != nil {
+		t.Fatalf("Failed to parse outline: %v", err)
+	}
+	if !s.Outline || len(s.Examples.Rows) != 2 || s.Examples.Header[1] != "result" {
+		t.Errorf("Expected an outline with 2 example rows, got %+v", s)
+	}
+	if placeholders := s.Placeholders(); len(placeholders) != 2 || placeholders[0] != "password" {
+		t.Errorf("Expected placeholders [password result], got %v", placeholders)
+	}
+	if s.String() != text {
+		t.Errorf("Expected round trip, got %q", s.String())
+	}
+
+	// Placeholders must be defined by the examples
+	broken := strings.Replace(text, "<result>", "<outcome>", 1)
+	if _, err := ParseScenario(broken); err == nil || !strings.Contains(err.Error(), "<outcome>") {
+		t.Errorf("Expected undefined placeholder error, got %v", err)
+	}
+}
+
+func TestScenarioValidate(t *testing.T) {
+	cases := map[string]string{
+		"":                                   "no steps",
+		"Given a user\nThen nothing happens": "missing When",
+		"When they log in\nGiven a user\nThen done": "Given after When",
+		"And a user\nWhen they log in\nThen done":   "And cannot start",
+		"The user can log in":                       "not a Given/When/Then step",
+	}
+	for text, expected := range cases {
+		_, err := ParseScenario(text)
+		if !errors.Is(err, ErrMalformedCriterion) || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q to fail with %q, got %v", text, expected, err)
+		}
+	}
+}
+
+func TestUserStoryScenarios(t *testing.T) {
+	us := NewUserStory("Logout", "")
+	us.AddAcceptanceCriterion("Given a user, when they log out, then the session ends")
+	us.AddAcceptanceCriterion("Users can log out")
+
+	// Structured scenarios are validated before they are added
+	if err := us.AddScenario(Scenario{Steps: []Step{{Keyword: KeywordThen, Text: "done"}}}); !errors.Is(err, ErrMalformedCriterion) {
+		t.Errorf("Expected error %v, got %v", ErrMalformedCriterion, err)
+	}
+	if len(us.AcceptanceCriteria) != 2 {
+		t.Errorf("Expected the invalid scenario not to be added, got %d criteria", len(us.AcceptanceCriteria))
+	}
+
+	// Each criterion is parsed independently
+	scenarios, errs := us.Scenarios()
+	if len(scenarios) != 2 || errs[0] != nil || errs[1] == nil {
+		t.Errorf("Expected the first criterion to parse and the second to fail, got %v", errs)
+	}
+}
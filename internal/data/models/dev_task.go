@@ -2,6 +2,7 @@
 package models
 
 import (
+	"encoding/json"
 	"math/rand"
 	"strconv"
 	"time"
@@ -36,6 +37,12 @@ type DevTask struct {
 	Status       TaskStatus    `json:"status"`
 	Assignee     string        `json:"assignee"`     // Developer ID
 	Dependencies []string      `json:"dependencies"` // IDs of dependent tasks
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	StartedAt    *time.Time    `json:"started_at,omitempty"`   // First move to InProgress
+	CompletedAt  *time.Time    `json:"completed_at,omitempty"` // Move to Done, cleared if reopened
+
+	recorder Recorder // Receives mutation events once tracked
 }
 
 // NewDevTask creates a new development task
@@ -49,6 +56,8 @@ func NewDevTask(storyID, title, assignee string) *DevTask {
 		Status:       TaskTodo,
 		Assignee:     assignee,
 		Dependencies: []string{},
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 }
 
@@ -58,11 +67,72 @@ func (dt *DevTask) Update(title string, taskType TaskType, estimate time.Duratio
 	dt.Type = taskType
 	dt.Estimate = estimate
 	dt.Assignee = assignee
+	dt.UpdatedAt = time.Now()
+	emit(dt.recorder, KindTask, dt.ID, EventTaskUpdated, taskUpdatedData{
+		Title:    title,
+		Type:     taskType,
+		Estimate: estimate,
+		Assignee: assignee,
+	})
 }
 
-// SetStatus updates the status of the task
-func (dt *DevTask) SetStatus(status TaskStatus) {
+// SetStatus moves the task to a new status, enforcing the task state machine.
+// Moving to Done requires every task listed in Dependencies to be passed in and Done.
+func (dt *DevTask) SetStatus(status TaskStatus, dependencies ...*DevTask) error {
+	from := dt.Status
+	if status == from {
+		return nil
+	}
+	if err := checkTransition(taskTransitions, KindTask, dt.ID, from, status, dt.taskGuard(status, dependencies)); err != nil {
+		return err
+	}
+
+	now := time.Now()
 	dt.Status = status
+	dt.UpdatedAt = now
+	dt.markStatus(status, now)
+	emit(dt.recorder, KindTask, dt.ID, EventTaskStatusSet, valueData[TaskStatus]{Value: status})
+	fireTransition(Transition{Kind: KindTask, EntityID: dt.ID, From: string(from), To: string(status), At: now})
+
+	return nil
+}
+
+// markStatus records when the task first started and when it was completed
+func (dt *DevTask) markStatus(status TaskStatus, at time.Time) {
+	switch status {
+	case TaskInProgress:
+		if dt.StartedAt == nil {
+			dt.StartedAt = &at
+		}
+		dt.CompletedAt = nil
+	case TaskDone:
+		dt.CompletedAt = &at
+	default:
+		dt.CompletedAt = nil
+	}
+}
+
+// CycleTime returns the time from the first start to completion, if the task is Done
+func (dt *DevTask) CycleTime() (time.Duration, bool) {
+	if dt.StartedAt == nil || dt.CompletedAt == nil {
+		return 0, false
+	}
+	return dt.CompletedAt.Sub(*dt.StartedAt), true
+}
+
+// LeadTime returns the time from creation to completion, if the task is Done
+func (dt *DevTask) LeadTime() (time.Duration, bool) {
+	if dt.CompletedAt == nil || dt.CreatedAt.IsZero() {
+		return 0, false
+	}
+	return dt.CompletedAt.Sub(dt.CreatedAt), true
+}
+
+// SetStory moves the task to another story, as when its story is split
+func (dt *DevTask) SetStory(storyID string) {
+	dt.StoryID = storyID
+	dt.UpdatedAt = time.Now()
+	emit(dt.recorder, KindTask, dt.ID, EventTaskStorySet, valueData[string]{Value: storyID})
 }
 
 // AddDependency adds a new dependency, preventing duplicates
@@ -73,6 +143,8 @@ func (dt *DevTask) AddDependency(dependencyID string) {
 		}
 	}
 	dt.Dependencies = append(dt.Dependencies, dependencyID)
+	dt.UpdatedAt = time.Now()
+	emit(dt.recorder, KindTask, dt.ID, EventTaskDependencyAdded, valueData[string]{Value: dependencyID})
 }
 
 // RemoveDependency removes a dependency
@@ -80,11 +152,65 @@ func (dt *DevTask) RemoveDependency(dependencyID string) {
 	for i, dep := range dt.Dependencies {
 		if dep == dependencyID {
 			dt.Dependencies = append(dt.Dependencies[:i], dt.Dependencies[i+1:]...)
+			dt.UpdatedAt = time.Now()
+			emit(dt.recorder, KindTask, dt.ID, EventTaskDependencyRemoved, valueData[string]{Value: dependencyID})
 			break
 		}
 	}
 }
 
+// Track attaches a recorder and records the task's current state as its creation event
+func (dt *DevTask) Track(r Recorder) {
+	dt.recorder = r
+	emit(r, KindTask, dt.ID, EventTaskCreated, dt)
+}
+
+// Attach sends the task's further events to a recorder without recording its creation again,
+// as when loading it from the log or handing it to another actor
+func (dt *DevTask) Attach(r Recorder) {
+	dt.recorder = r
+}
+
+// Apply replays a recorded event onto the task without emitting new events
+func (dt *DevTask) Apply(e Event) error {
+	var err error
+	switch e.Type {
+	case EventTaskCreated:
+		err = json.Unmarshal(e.Data, dt)
+	case EventTaskUpdated:
+		var d taskUpdatedData
+		if err = json.Unmarshal(e.Data, &d); err == nil {
+			dt.Title = d.Title
+			dt.Type = d.Type
+			dt.Estimate = d.Estimate
+			dt.Assignee = d.Assignee
+		}
+	case EventTaskStatusSet:
+		var status TaskStatus
+		if status, err = decodeValue[TaskStatus](e); err == nil {
+			dt.Status = status
+			dt.markStatus(status, e.Timestamp)
+		}
+	case EventTaskStorySet:
+		dt.StoryID, err = decodeValue[string](e)
+	case EventTaskDependencyAdded, EventTaskDependencyRemoved:
+		var dependencyID string
+		if dependencyID, err = decodeValue[string](e); err == nil {
+			if e.Type == EventTaskDependencyAdded {
+				dt.Dependencies = append(dt.Dependencies, dependencyID)
+			} else {
+				dt.Dependencies = remove(dt.Dependencies, dependencyID)
+			}
+		}
+	default:
+		return ErrUnknownEvent
+	}
+	if err == nil && e.Type != EventTaskCreated {
+		dt.UpdatedAt = e.Timestamp
+	}
+	return err
+}
+
 // generateIDDT generates a unique ID for a development task
 func generateIDDT() string {
 	return "task-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
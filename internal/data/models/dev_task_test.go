@@ -137,6 +137,70 @@ func TestDevTaskRemoveDependency(t *testing.T) {
 	}
 }
 
+func TestDevTaskSetStory(t *testing.T) {
+	r := &recorderStub{}
+	dt := NewDevTask("story-1", "Implement login feature", "dev-1")
+	dt.Track(r)
+	dt.SetStory("story-2")
+
+	// Check that the task moved to the new story
+	if dt.StoryID != "story-2" {
+		t.Errorf("Expected StoryID to be 'story-2', got %s", dt.StoryID)
+	}
+
+	// Check that replaying the events moves it as well
+	replayed := &DevTask{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+	if replayed.StoryID != "story-2" {
+		t.Errorf("Expected replayed StoryID to be 'story-2', got %s", replayed.StoryID)
+	}
+}
+
+func TestDevTaskTimestamps(t *testing.T) {
+	r := &recorderStub{}
+	dt := NewDevTask("story-1", "Implement login feature", "dev-1")
+	dt.Track(r)
+
+	// Check that a new task has creation times and no cycle time yet
+	if dt.CreatedAt.IsZero() || dt.UpdatedAt.IsZero() || dt.StartedAt != nil {
+		t.Errorf("Expected CreatedAt and UpdatedAt only, got %+v", dt)
+	}
+	if _, ok := dt.CycleTime(); ok {
+		t.Errorf("Expected no cycle time before completion")
+	}
+
+	dt.SetStatus(TaskInProgress)
+	started := *dt.StartedAt
+	dt.SetStatus(TaskBlocked)
+	dt.SetStatus(TaskInProgress)
+	dt.SetStatus(TaskDone)
+
+	// Check that the first start is kept and completion is set
+	if !dt.StartedAt.Equal(started) || dt.CompletedAt == nil {
+		t.Errorf("Expected the first start and a completion, got %v and %v", dt.StartedAt, dt.CompletedAt)
+	}
+	cycle, ok := dt.CycleTime()
+	lead, _ := dt.LeadTime()
+	if !ok || cycle < 0 || lead < cycle {
+		t.Errorf("Expected lead time %v to cover cycle time %v", lead, cycle)
+	}
+
+	// Check that replaying the events restores the times from the event timestamps
+	replayed := &DevTask{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+	if replayed.StartedAt == nil || replayed.CompletedAt == nil || !replayed.UpdatedAt.Equal(r.events[len(r.events)-1].Timestamp) {
+		t.Errorf("Expected replayed start, completion and update times, got %+v", replayed)
+	}
+}
+
 func TestDevTaskgenerateIDDT(t *testing.T) {
 	// Test that generateIDDT creates unique IDs
 	id1 := generateIDDT()
@@ -0,0 +1,100 @@
+// Epic represents a group of related user stories on the product roadmap
+package models
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// Epic represents a large body of work split into user stories
+type Epic struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ReleaseID   string    `json:"release_id,omitempty"` // Parent release, if any
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Progress is the roll-up of story points and story counts below an epic or release
+type Progress struct {
+	Stories     int `json:"stories"`
+	DoneStories int `json:"done_stories"`
+	Points      int `json:"points"`
+	DonePoints  int `json:"done_points"`
+}
+
+// Percent returns the share of done points, falling back to done stories when nothing is estimated
+func (p Progress) Percent() float64 {
+	if p.Points > 0 {
+		return 100 * float64(p.DonePoints) / float64(p.Points)
+	}
+	if p.Stories > 0 {
+		return 100 * float64(p.DoneStories) / float64(p.Stories)
+	}
+	return 0
+}
+
+// add rolls a story into the progress
+func (p *Progress) add(us *UserStory) {
+	points := 0
+	if us.Estimate != nil {
+		points = us.Estimate.Points
+	}
+	p.Stories++
+	p.Points += points
+	if us.Status == StoryDone {
+		p.DoneStories++
+		p.DonePoints += points
+	}
+}
+
+// NewEpic creates a new epic
+func NewEpic(title, description string) *Epic {
+	return &Epic{
+		ID:          generateIDE(),
+		Title:       title,
+		Description: description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// generateIDE generates a unique ID for an epic
+func generateIDE() string {
+	return "epic-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// Update updates the epic with new values
+func (e *Epic) Update(title, description string) {
+	e.Title = title
+	e.Description = description
+	e.UpdatedAt = time.Now()
+}
+
+// SetRelease links the epic to its parent release; an empty ID detaches it
+func (e *Epic) SetRelease(releaseID string) {
+	e.ReleaseID = releaseID
+	e.UpdatedAt = time.Now()
+}
+
+// Stories returns the stories whose parent is this epic
+func (e *Epic) Stories(stories []*UserStory) []*UserStory {
+	var children []*UserStory
+	for _, us := range stories {
+		if us.EpicID == e.ID {
+			children = append(children, us)
+		}
+	}
+	return children
+}
+
+// Progress rolls up the points and status of the epic's stories
+func (e *Epic) Progress(stories []*UserStory) Progress {
+	var p Progress
+	for _, us := range e.Stories(stories) {
+		p.add(us)
+	}
+	return p
+}
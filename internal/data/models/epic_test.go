@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestNewEpic(t *testing.T) {
+	e := NewEpic("User accounts", "Everything about signing up and logging in")
+
+	// Check that the epic has a valid ID
+	if !startsWith(e.ID, "epic-") {
+		t.Errorf("Expected ID to start with 'epic-', got %s", e.ID)
+	}
+
+	// Check that the epic is not yet part of a release
+	if e.ReleaseID != "" {
+		t.Errorf("Expected ReleaseID to be empty, got %s", e.ReleaseID)
+	}
+	if e.CreatedAt.IsZero() || e.UpdatedAt.IsZero() {
+		t.Errorf("Expected CreatedAt and UpdatedAt to be non-zero")
+	}
+}
+
+func TestEpicProgress(t *testing.T) {
+	e := NewEpic("User accounts", "")
+
+	done := newEstimatedStory("story-1", 5)
+	done.SetEpic(e.ID)
+	done.AddAcceptanceCriterion("User can log in")
+	done.SetStatus(StoryReady)
+	done.SetStatus(StoryInProgress)
+	done.SetStatus(StoryDone)
+
+	open := newEstimatedStory("story-2", 3)
+	open.SetEpic(e.ID)
+
+	other := newEstimatedStory("story-3", 8)
+
+	// Check that only the epic's stories are rolled up
+	p := e.Progress([]*UserStory{done, open, other})
+	if p.Stories != 2 || p.DoneStories != 1 {
+		t.Errorf("Expected 1 of 2 stories done, got %d of %d", p.DoneStories, p.Stories)
+	}
+	if p.Points != 8 || p.DonePoints != 5 {
+		t.Errorf("Expected 5 of 8 points done, got %d of %d", p.DonePoints, p.Points)
+	}
+	if p.Percent() != 62.5 {
+		t.Errorf("Expected 62.5%% progress, got %v", p.Percent())
+	}
+}
+
+func TestProgressPercentWithoutPoints(t *testing.T) {
+	// Unestimated work falls back to story counts
+	p := Progress{Stories: 4, DoneStories: 1}
+	if p.Percent() != 25 {
+		t.Errorf("Expected 25%% progress, got %v", p.Percent())
+	}
+	if (Progress{}).Percent() != 0 {
+		t.Errorf("Expected 0%% progress for an empty roll-up, got %v", (Progress{}).Percent())
+	}
+}
@@ -0,0 +1,111 @@
+// Estimation represents the outcome and history of estimating a user story
+package models
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// FibonacciScale is the set of story points estimators may vote
+var FibonacciScale = []int{0, 1, 2, 3, 5, 8, 13, 21, 34}
+
+// ScaleIndex returns the position of the points on the Fibonacci scale, or -1 if they are not on it
+func ScaleIndex(points int) int {
+	for i, p := range FibonacciScale {
+		if p == points {
+			return i
+		}
+	}
+	return -1
+}
+
+// ThreePointEstimate is an optimistic, most likely and pessimistic estimate in hours
+type ThreePointEstimate struct {
+	Optimistic  float64 `json:"optimistic"`
+	Likely      float64 `json:"likely"`
+	Pessimistic float64 `json:"pessimistic"`
+}
+
+// IsZero reports whether no hours were given
+func (t ThreePointEstimate) IsZero() bool {
+	return t.Optimistic == 0 && t.Likely == 0 && t.Pessimistic == 0
+}
+
+// Valid reports whether the hours are positive and ordered optimistic <= likely <= pessimistic
+func (t ThreePointEstimate) Valid() bool {
+	return t.Optimistic > 0 && t.Optimistic <= t.Likely && t.Likely <= t.Pessimistic
+}
+
+// Expected returns the PERT expected hours (O + 4M + P) / 6
+func (t ThreePointEstimate) Expected() float64 {
+	return (t.Optimistic + 4*t.Likely + t.Pessimistic) / 6
+}
+
+// StdDev returns the PERT standard deviation (P - O) / 6
+func (t ThreePointEstimate) StdDev() float64 {
+	return (t.Pessimistic - t.Optimistic) / 6
+}
+
+// EstimationVote is one estimator's card in a round
+type EstimationVote struct {
+	EstimatorID   string              `json:"estimator_id"`
+	Points        int                 `json:"points"`
+	Hours         *ThreePointEstimate `json:"hours,omitempty"`
+	Justification string              `json:"justification,omitempty"` // Given when asked as an outlier
+}
+
+// EstimationRound is one reveal of the cards
+type EstimationRound struct {
+	Number   int              `json:"number"`
+	Votes    []EstimationVote `json:"votes"`
+	Outliers []string         `json:"outliers,omitempty"` // Estimators asked to justify the lowest and highest votes
+}
+
+// EstimationSession is the history of one planning poker session on a story
+type EstimationSession struct {
+	ID         string              `json:"id"`
+	StoryID    string              `json:"story_id"`
+	StartedAt  time.Time           `json:"started_at"`
+	EndedAt    time.Time           `json:"ended_at"`
+	Estimators []string            `json:"estimators"`
+	Rounds     []EstimationRound   `json:"rounds"`
+	Consensus  bool                `json:"consensus"` // False when the session ran out of rounds
+	Points     int                 `json:"points"`
+	Confidence float64             `json:"confidence"` // 0-1
+	Hours      *ThreePointEstimate `json:"hours,omitempty"`
+}
+
+// NewEstimationSession creates a session for the story and estimators
+func NewEstimationSession(storyID string, estimators ...string) *EstimationSession {
+	return &EstimationSession{
+		ID:         generateIDES(),
+		StoryID:    storyID,
+		StartedAt:  time.Now(),
+		Estimators: estimators,
+		Rounds:     []EstimationRound{},
+	}
+}
+
+// generateIDES generates a unique ID for an estimation session
+func generateIDES() string {
+	return "estimation-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// RecordEstimation stores the outcome of an estimation session and adds it to the story's history
+func (us *UserStory) RecordEstimation(session EstimationSession) {
+	applyEstimation(us, session)
+	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryEstimated, session)
+}
+
+// applyEstimation copies the session's outcome onto the story's estimate
+func applyEstimation(us *UserStory, session EstimationSession) {
+	if us.Estimate == nil {
+		us.Estimate = &StoryEstimate{}
+	}
+	us.Estimate.Points = session.Points
+	us.Estimate.Confidence = session.Confidence
+	us.Estimate.Hours = session.Hours
+	us.Estimate.History = append(us.Estimate.History, session)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestThreePointEstimate(t *testing.T) {
+	hours := ThreePointEstimate{Optimistic: 2, Likely: 5, Pessimistic: 14}
+
+	// Check the PERT expected value and deviation
+	if hours.Expected() != 6 || hours.StdDev() != 2 {
+		t.Errorf("Expected 6h +/- 2h, got %vh +/- %vh", hours.Expected(), hours.StdDev())
+	}
+
+	// Check that unordered or missing hours are invalid
+	if !hours.Valid() || (ThreePointEstimate{Optimistic: 5, Likely: 2, Pessimistic: 14}).Valid() || (ThreePointEstimate{}).Valid() {
+		t.Errorf("Expected only ordered positive hours to be valid")
+	}
+}
+
+func TestScaleIndex(t *testing.T) {
+	// Check positions on the Fibonacci scale
+	if ScaleIndex(0) != 0 || ScaleIndex(5) != 4 || ScaleIndex(4) != -1 {
+		t.Errorf("Expected indexes 0, 4 and -1, got %d, %d and %d", ScaleIndex(0), ScaleIndex(5), ScaleIndex(4))
+	}
+}
+
+func TestUserStoryRecordEstimation(t *testing.T) {
+	r := &recorderStub{}
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Track(r)
+
+	session := NewEstimationSession(us.ID, "dev-1", "dev-2")
+	session.Points = 5
+	session.Confidence = 1
+	session.Consensus = true
+	session.Hours = &ThreePointEstimate{Optimistic: 4, Likely: 6, Pessimistic: 10}
+	us.RecordEstimation(*session)
+
+	// Check that the outcome was stored with the history
+	if us.Estimate.Points != 5 || us.Estimate.Confidence != 1 || us.Estimate.Hours.Likely != 6 || len(us.Estimate.History) != 1 {
+		t.Errorf("Expected 5 points, confidence 1, 6 likely hours and one session, got %+v", us.Estimate)
+	}
+	if !strings.HasPrefix(session.ID, "estimation-") {
+		t.Errorf("Expected session ID to start with 'estimation-', got %s", session.ID)
+	}
+
+	// Check that replaying the events restores the estimate
+	replayed := &UserStory{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+	if replayed.Estimate.Points != 5 || len(replayed.Estimate.History) != 1 || math.Abs(replayed.Estimate.Hours.Expected()-us.Estimate.Hours.Expected()) > 1e-9 {
+		t.Errorf("Expected replayed estimate to match, got %+v", replayed.Estimate)
+	}
+}
@@ -0,0 +1,178 @@
+// Event represents a domain event recorded for every model mutation
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EntityKind identifies the kind of model an event belongs to
+type EntityKind string
+
+const (
+	KindStory      EntityKind = "story"
+	KindTask       EntityKind = "task"
+	KindSprint     EntityKind = "sprint"
+	KindImpediment EntityKind = "impediment" // Labels transitions and search results, impediments are not event sourced
+)
+
+// EventType identifies the mutation recorded by an event
+type EventType string
+
+const (
+	EventStoryCreated        EventType = "story.created"
+	EventStoryUpdated        EventType = "story.updated"
+	EventStoryStatusSet      EventType = "story.status_set"
+	EventStoryCriterionAdded EventType = "story.criterion_added"
+	EventStoryEstimateSet    EventType = "story.estimate_set"
+	EventStoryEstimated      EventType = "story.estimated"
+	EventStoryEpicSet        EventType = "story.epic_set"
+	EventStoryScoringSet     EventType = "story.scoring_set"
+	EventStoryRankSet        EventType = "story.rank_set"
+	EventStoryParentSet      EventType = "story.parent_set"
+	EventStoryChildAdded     EventType = "story.child_added"
+
+	EventTaskCreated           EventType = "task.created"
+	EventTaskUpdated           EventType = "task.updated"
+	EventTaskStatusSet         EventType = "task.status_set"
+	EventTaskDependencyAdded   EventType = "task.dependency_added"
+	EventTaskDependencyRemoved EventType = "task.dependency_removed"
+	EventTaskStorySet          EventType = "task.story_set"
+
+	EventSprintCreated          EventType = "sprint.created"
+	EventSprintStatusSet        EventType = "sprint.status_set"
+	EventSprintStoryCommitted   EventType = "sprint.story_committed"
+	EventSprintStoryUncommitted EventType = "sprint.story_uncommitted"
+	EventSprintStoryCompleted   EventType = "sprint.story_completed"
+	EventSprintStoryReopened    EventType = "sprint.story_reopened"
+	EventSprintVelocitySet      EventType = "sprint.velocity_set"
+	EventSprintBurnDownAdded    EventType = "sprint.burn_down_added"
+	EventSprintBurnDownRecorded EventType = "sprint.burn_down_recorded"
+
+	// EventSprintBurnDownRefreshed is derived from the sprint change recorded just before it
+	EventSprintBurnDownRefreshed EventType = "sprint.burn_down_refreshed"
+
+	// EventUndo reverts the event whose sequence number is stored in Data
+	EventUndo EventType = "undo"
+)
+
+// Event represents a single mutation of a model
+type Event struct {
+	Seq       int64           `json:"seq"` // Assigned by the event store
+	Kind      EntityKind      `json:"kind"`
+	Type      EventType       `json:"type"`
+	EntityID  string          `json:"entity_id"`
+	Timestamp time.Time       `json:"timestamp"`
+	Actor     string          `json:"actor,omitempty"` // Agent or member who made the change, if known
+	Data      json.RawMessage `json:"data"`
+}
+
+// Recorder receives the events emitted by tracked models
+type Recorder interface {
+	// Record appends the event to the underlying log
+	Record(event Event)
+}
+
+// actorRecorder stamps events with the actor making the changes
+type actorRecorder struct {
+	next  Recorder
+	actor string
+}
+
+// WithActor returns a recorder attributing the events it passes on to the actor,
+// such as the agent type or member ID. Events that already name an actor keep it.
+// Attach a model to another actor's recorder when someone else makes the next changes.
+func WithActor(r Recorder, actor string) Recorder {
+	return actorRecorder{next: r, actor: actor}
+}
+
+func (a actorRecorder) Record(event Event) {
+	if event.Actor == "" {
+		event.Actor = a.actor
+	}
+	a.next.Record(event)
+}
+
+// Event payloads, kept unexported so the wire format stays owned by this package
+type storyUpdatedData struct {
+	Title         string        `json:"title"`
+	Description   string        `json:"description"`
+	Priority      PriorityLevel `json:"priority"`
+	BusinessValue int           `json:"business_value"`
+}
+
+type storyScoringData struct {
+	TimeCriticality int    `json:"time_criticality"`
+	RiskReduction   int    `json:"risk_reduction"`
+	MoSCoW          MoSCoW `json:"moscow"`
+}
+
+type taskUpdatedData struct {
+	Title    string        `json:"title"`
+	Type     TaskType      `json:"type"`
+	Estimate time.Duration `json:"estimate"`
+	Assignee string        `json:"assignee"`
+}
+
+type valueData[T any] struct {
+	Value T `json:"value"`
+}
+
+// emit builds an event and hands it to the recorder, if any
+func emit(r Recorder, kind EntityKind, id string, eventType EventType, data any) {
+	if r == nil {
+		return
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return // Payloads are plain structs; this cannot happen in practice
+	}
+	r.Record(Event{
+		Kind:      kind,
+		Type:      eventType,
+		EntityID:  id,
+		Timestamp: time.Now(),
+		Data:      raw,
+	})
+}
+
+// Derived reports whether the event follows from the change recorded just before it rather
+// than from a change of its own, so undoing that change reverts both
+func (e Event) Derived() bool {
+	return e.Type == EventSprintBurnDownRefreshed
+}
+
+// decodeValue unmarshals a single-value payload
+func decodeValue[T any](e Event) (T, error) {
+	var d valueData[T]
+	err := json.Unmarshal(e.Data, &d)
+	return d.Value, err
+}
+
+// UndoTarget returns the sequence number reverted by an EventUndo event
+func (e Event) UndoTarget() (int64, error) {
+	if e.Type != EventUndo {
+		return 0, ErrUnknownEvent
+	}
+	return decodeValue[int64](e)
+}
+
+// NewUndoEvent creates an event that reverts the event with the given sequence number
+func NewUndoEvent(seq int64) Event {
+	raw, _ := json.Marshal(valueData[int64]{Value: seq})
+	return Event{
+		Type:      EventUndo,
+		Timestamp: time.Now(),
+		Data:      raw,
+	}
+}
+
+// modelError implements the error interface
+type modelError string
+
+func (e modelError) Error() string {
+	return string(e)
+}
+
+// ErrUnknownEvent is returned when an event cannot be applied to a model
+var ErrUnknownEvent = modelError("unknown event type")
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// recorderStub collects recorded events in memory
+type recorderStub struct {
+	events []Event
+}
+
+func (r *recorderStub) Record(event Event) {
+	event.Seq = int64(len(r.events)) + 1
+	r.events = append(r.events, event)
+}
+
+func TestUserStoryTrackEmitsEvents(t *testing.T) {
+	r := &recorderStub{}
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+
+	// Untracked mutations should not be recorded
+	us.SetEstimate(3)
+	if len(r.events) != 0 {
+		t.Errorf("Expected no events before tracking, got %d", len(r.events))
+	}
+
+	us.Track(r)
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	us.SetStatus(StoryReady)
+
+	// Check that the creation event and both mutations were recorded
+	expected := []EventType{EventStoryCreated, EventStoryCriterionAdded, EventStoryStatusSet}
+	if len(r.events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(r.events))
+	}
+	for i, e := range r.events {
+		if e.Type != expected[i] {
+			t.Errorf("Expected event %d to be %s, got %s", i, expected[i], e.Type)
+		}
+		if e.Kind != KindStory || e.EntityID != us.ID {
+			t.Errorf("Expected event %d to belong to story %s, got %s %s", i, us.ID, e.Kind, e.EntityID)
+		}
+	}
+}
+
+func TestUserStoryApplyReplaysEvents(t *testing.T) {
+	r := &recorderStub{}
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Track(r)
+	us.Update("Fix authentication bug", "Improve security", PriorityHigh, 8)
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	us.SetEstimate(5)
+	us.SetStatus(StoryReady)
+
+	// Replay the events onto an empty story
+	replayed := &UserStory{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+
+	// Check that the replayed story matches the original
+	if replayed.ID != us.ID || replayed.Title != us.Title || replayed.Priority != us.Priority {
+		t.Errorf("Expected replayed story to match original, got %+v", replayed)
+	}
+	if replayed.Status != StoryReady {
+		t.Errorf("Expected Status to be StoryReady, got %s", replayed.Status)
+	}
+	if replayed.Estimate.Points != 5 {
+		t.Errorf("Expected Estimate Points to be 5, got %d", replayed.Estimate.Points)
+	}
+	if len(replayed.AcceptanceCriteria) != 1 {
+		t.Errorf("Expected 1 acceptance criterion, got %d", len(replayed.AcceptanceCriteria))
+	}
+
+	// Replaying must not emit new events
+	if len(r.events) != 5 {
+		t.Errorf("Expected 5 events, got %d", len(r.events))
+	}
+}
+
+func TestDevTaskApplyReplaysEvents(t *testing.T) {
+	r := &recorderStub{}
+	dt := NewDevTask("story-1", "Implement login feature", "dev-1")
+	dt.Track(r)
+	dt.AddDependency("task-1")
+	dt.AddDependency("task-2")
+	dt.RemoveDependency("task-1")
+	dt.Update("Write login tests", TaskTesting, time.Hour*2, "dev-2")
+	dt.SetStatus(TaskInProgress)
+
+	replayed := &DevTask{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+
+	// Check that the replayed task matches the original
+	if replayed.Title != dt.Title || replayed.Type != TaskTesting || replayed.Assignee != "dev-2" {
+		t.Errorf("Expected replayed task to match original, got %+v", replayed)
+	}
+	if replayed.Status != TaskInProgress {
+		t.Errorf("Expected Status to be TaskInProgress, got %s", replayed.Status)
+	}
+	if len(replayed.Dependencies) != 1 || replayed.Dependencies[0] != "task-2" {
+		t.Errorf("Expected Dependencies to be [task-2], got %v", replayed.Dependencies)
+	}
+}
+
+func TestSprintApplyReplaysEvents(t *testing.T) {
+	r := &recorderStub{}
+	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+	s.Track(r)
+	s.AddCommittedStory("story-1")
+	s.AddCommittedStory("story-2")
+	s.RemoveCommittedStory("story-2")
+	s.AddCompletedStory("story-1")
+	s.SetVelocity(13)
+	s.AddBurnDownPoint(time.Now(), 8)
+
+	replayed := &Sprint{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+
+	// Check that the replayed sprint matches the original
+	if len(replayed.Committed) != 1 || replayed.Committed[0] != "story-1" {
+		t.Errorf("Expected Committed to be [story-1], got %v", replayed.Committed)
+	}
+	if len(replayed.Completed) != 1 {
+		t.Errorf("Expected Completed length to be 1, got %d", len(replayed.Completed))
+	}
+	if replayed.Velocity != 13 {
+		t.Errorf("Expected Velocity to be 13, got %d", replayed.Velocity)
+	}
+	if len(replayed.BurnDown) != 1 || replayed.BurnDown[0].Left != 8 {
+		t.Errorf("Expected one burn-down point with 8 left, got %v", replayed.BurnDown)
+	}
+}
+
+func TestApplyUnknownEvent(t *testing.T) {
+	us := &UserStory{}
+	err := us.Apply(Event{Type: EventTaskStatusSet})
+	if err != ErrUnknownEvent {
+		t.Errorf("Expected error %v, got %v", ErrUnknownEvent, err)
+	}
+}
+
+func TestUndoEvent(t *testing.T) {
+	e := NewUndoEvent(42)
+
+	target, err := e.UndoTarget()
+	if err != nil {
+		t.Fatalf("Failed to read undo target: %v", err)
+	}
+	if target != 42 {
+		t.Errorf("Expected undo target to be 42, got %d", target)
+	}
+
+	// Non-undo events have no target
+	if _, err := (Event{Type: EventStoryCreated}).UndoTarget(); err == nil {
+		t.Error("Expected error reading undo target of a non-undo event")
+	}
+}
+
+func TestWithActor(t *testing.T) {
+	r := &recorderStub{}
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Track(WithActor(r, "po"))
+	us.SetEstimate(3)
+
+	// Check that every event names the actor
+	for _, e := range r.events {
+		if e.Actor != "po" {
+			t.Errorf("Expected actor po on %s, got %q", e.Type, e.Actor)
+		}
+	}
+
+	// Check that an actor already set is kept
+	WithActor(r, "sm").Record(Event{Type: EventStoryUpdated, Actor: "dev-1"})
+	if last := r.events[len(r.events)-1]; last.Actor != "dev-1" {
+		t.Errorf("Expected actor dev-1 to be kept, got %q", last.Actor)
+	}
+}
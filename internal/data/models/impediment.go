@@ -0,0 +1,258 @@
+// Impediment represents an obstacle slowing down the team, as reported to the SM agent
+package models
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ImpedimentScope represents how much of the sprint an impediment affects
+type ImpedimentScope string
+
+const (
+	ScopeSingleTask    ImpedimentScope = "SingleTask"
+	ScopeMultipleTasks ImpedimentScope = "MultipleTasks"
+	ScopeSprint        ImpedimentScope = "Sprint"
+)
+
+// Urgency represents how quickly an impediment must be resolved
+type Urgency string
+
+const (
+	UrgencyLow      Urgency = "Low"
+	UrgencyMedium   Urgency = "Medium"
+	UrgencyHigh     Urgency = "High"
+	UrgencyCritical Urgency = "Critical"
+)
+
+// ImpedimentCategory represents the kind of obstacle
+type ImpedimentCategory string
+
+const (
+	CategoryTechnical      ImpedimentCategory = "Technical"
+	CategoryProcess        ImpedimentCategory = "Process"
+	CategoryOrganizational ImpedimentCategory = "Organizational"
+	CategoryExternal       ImpedimentCategory = "External"
+)
+
+// ImpedimentStatus represents the status of an impediment
+type ImpedimentStatus string
+
+const (
+	ImpedimentOpen       ImpedimentStatus = "Open"
+	ImpedimentInProgress ImpedimentStatus = "InProgress"
+	ImpedimentResolved   ImpedimentStatus = "Resolved"
+)
+
+// ResolutionPlan holds the actions planned against an impediment
+type ResolutionPlan struct {
+	Immediate []string `json:"immediate"`  // Within 24 hours
+	ShortTerm []string `json:"short_term"` // Within the week
+	LongTerm  []string `json:"long_term"`  // Preventing recurrence
+}
+
+// Impediment represents an obstacle report and its resolution
+type Impediment struct {
+	ID          string                `json:"id"`
+	ReportedAt  time.Time             `json:"reported_at"`
+	Reporter    string                `json:"reporter"` // Member ID
+	Description string                `json:"description"`
+	Category    ImpedimentCategory    `json:"category"`
+	Scope       ImpedimentScope       `json:"scope"`
+	Urgency     Urgency               `json:"urgency"`
+	Impact      string                `json:"impact"` // Effect on delivery
+	RootCause   string                `json:"root_cause"`
+	Actions     ResolutionPlan        `json:"actions"`
+	Owner       string                `json:"owner"` // Member ID
+	DueDate     time.Time             `json:"due_date"`
+	Status      ImpedimentStatus      `json:"status"`
+	TaskIDs     []string              `json:"task_ids"`     // Tasks blocked by the impediment
+	BlockedFrom map[string]TaskStatus `json:"blocked_from"` // Status of each task before it was blocked
+	Resolution  string                `json:"resolution,omitempty"`
+	ResolvedAt  *time.Time            `json:"resolved_at,omitempty"`
+}
+
+// NewImpediment creates a new open impediment
+func NewImpediment(reporter, description string, urgency Urgency) *Impediment {
+	return &Impediment{
+		ID:          generateIDI(),
+		ReportedAt:  time.Now(),
+		Reporter:    reporter,
+		Description: description,
+		Scope:       ScopeSingleTask,
+		Urgency:     urgency,
+		Status:      ImpedimentOpen,
+		TaskIDs:     []string{},
+		BlockedFrom: make(map[string]TaskStatus),
+	}
+}
+
+// generateIDI generates a unique ID for an impediment
+func generateIDI() string {
+	return "impediment-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// Analyze records the analysis of the impediment
+func (im *Impediment) Analyze(category ImpedimentCategory, scope ImpedimentScope, impact, rootCause string) {
+	im.Category = category
+	im.Scope = scope
+	im.Impact = impact
+	im.RootCause = rootCause
+}
+
+// Assign gives the impediment an owner and due date and starts working on it
+func (im *Impediment) Assign(owner string, dueDate time.Time, actions ResolutionPlan) error {
+	im.Owner = owner
+	im.DueDate = dueDate
+	im.Actions = actions
+	return im.SetStatus(ImpedimentInProgress)
+}
+
+// SetStatus moves the impediment to a new status, enforcing the impediment state machine
+func (im *Impediment) SetStatus(status ImpedimentStatus) error {
+	from := im.Status
+	if status == from {
+		return nil
+	}
+	if err := checkTransition(impedimentTransitions, KindImpediment, im.ID, from, status, nil); err != nil {
+		return err
+	}
+
+	im.Status = status
+	if status == ImpedimentResolved {
+		now := time.Now()
+		im.ResolvedAt = &now
+	} else {
+		im.ResolvedAt = nil
+	}
+	fireTransition(Transition{Kind: KindImpediment, EntityID: im.ID, From: string(from), To: string(status), At: time.Now()})
+
+	return nil
+}
+
+// Block links the tasks to the impediment and moves them to Blocked, remembering their status.
+// Tasks that cannot be blocked, such as Done tasks, stop the linking with an error.
+func (im *Impediment) Block(tasks ...*DevTask) error {
+	for _, task := range tasks {
+		from := task.Status
+		if err := task.SetStatus(TaskBlocked); err != nil {
+			return err
+		}
+		if !contains(im.TaskIDs, task.ID) {
+			im.TaskIDs = append(im.TaskIDs, task.ID)
+		}
+		if _, ok := im.BlockedFrom[task.ID]; !ok && from != TaskBlocked {
+			im.BlockedFrom[task.ID] = from
+		}
+	}
+	if len(im.TaskIDs) > 1 && im.Scope == ScopeSingleTask {
+		im.Scope = ScopeMultipleTasks
+	}
+	return nil
+}
+
+// Resolve closes the impediment and returns the given linked tasks that are still Blocked
+// to the status they had before. Tasks also blocked by another open impediment should be left out.
+func (im *Impediment) Resolve(resolution string, tasks ...*DevTask) error {
+	if err := im.SetStatus(ImpedimentResolved); err != nil {
+		return err
+	}
+	im.Resolution = resolution
+
+	for _, task := range tasks {
+		if !contains(im.TaskIDs, task.ID) || task.Status != TaskBlocked {
+			continue
+		}
+		to, ok := im.BlockedFrom[task.ID]
+		if !ok {
+			to = TaskTodo
+		}
+		if err := task.SetStatus(to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TimeToResolve returns the time from report to resolution, false while unresolved
+func (im *Impediment) TimeToResolve() (time.Duration, bool) {
+	if im.ResolvedAt == nil {
+		return 0, false
+	}
+	return im.ResolvedAt.Sub(im.ReportedAt), true
+}
+
+// Age returns how long the impediment has been open, or its time to resolve once resolved
+func (im *Impediment) Age(now time.Time) time.Duration {
+	if d, ok := im.TimeToResolve(); ok {
+		return d
+	}
+	return now.Sub(im.ReportedAt)
+}
+
+// Overdue reports whether the impediment is unresolved past its due date
+func (im *Impediment) Overdue(now time.Time) bool {
+	return im.Status != ImpedimentResolved && !im.DueDate.IsZero() && now.After(im.DueDate)
+}
+
+// ImpedimentStats summarizes impediments for the SM agent's progress report
+type ImpedimentStats struct {
+	Open           int           `json:"open"`
+	InProgress     int           `json:"in_progress"`
+	Resolved       int           `json:"resolved"`
+	AverageResolve time.Duration `json:"average_resolve"` // Mean time to resolve over resolved impediments
+	LongestOpen    time.Duration `json:"longest_open"`    // Age of the oldest unresolved impediment
+}
+
+// SummarizeImpediments counts impediments by status and computes resolution times at the given time
+func SummarizeImpediments(impediments []*Impediment, now time.Time) ImpedimentStats {
+	var stats ImpedimentStats
+	var total time.Duration
+	for _, im := range impediments {
+		switch im.Status {
+		case ImpedimentOpen:
+			stats.Open++
+		case ImpedimentInProgress:
+			stats.InProgress++
+		case ImpedimentResolved:
+			stats.Resolved++
+			d, _ := im.TimeToResolve()
+			total += d
+			continue
+		}
+		if age := im.Age(now); age > stats.LongestOpen {
+			stats.LongestOpen = age
+		}
+	}
+	if stats.Resolved > 0 {
+		stats.AverageResolve = total / time.Duration(stats.Resolved)
+	}
+	return stats
+}
+
+// OpenImpediments returns the unresolved impediments, most urgent first and oldest first within an urgency
+func OpenImpediments(impediments []*Impediment) []*Impediment {
+	var open []*Impediment
+	for _, im := range impediments {
+		if im.Status != ImpedimentResolved {
+			open = append(open, im)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		if urgencyOrder[open[i].Urgency] != urgencyOrder[open[j].Urgency] {
+			return urgencyOrder[open[i].Urgency] > urgencyOrder[open[j].Urgency]
+		}
+		return open[i].ReportedAt.Before(open[j].ReportedAt)
+	})
+	return open
+}
+
+// urgencyOrder ranks urgencies for sorting
+var urgencyOrder = map[Urgency]int{
+	UrgencyLow:      1,
+	UrgencyMedium:   2,
+	UrgencyHigh:     3,
+	UrgencyCritical: 4,
+}
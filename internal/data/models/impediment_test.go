@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewImpediment(t *testing.T) {
+	im := NewImpediment("dev-1", "Staging database is down", UrgencyHigh)
+
+	// Check the defaults
+	if !startsWith(im.ID, "impediment-") {
+		t.Errorf("Expected ID to start with 'impediment-', got %s", im.ID)
+	}
+	if im.Status != ImpedimentOpen {
+		t.Errorf("Expected Status to be Open, got %s", im.Status)
+	}
+	if im.Scope != ScopeSingleTask {
+		t.Errorf("Expected Scope to be SingleTask, got %s", im.Scope)
+	}
+}
+
+func TestImpedimentBlockAndResolve(t *testing.T) {
+	coding := &DevTask{ID: "coding", Status: TaskInProgress}
+	testTask := &DevTask{ID: "testing", Status: TaskTodo}
+	im := NewImpediment("dev-1", "Staging database is down", UrgencyHigh)
+
+	// Blocking moves the tasks to Blocked and widens the scope
+	if err := im.Block(coding, testTask); err != nil {
+		t.Fatalf("Failed to block tasks: %v", err)
+	}
+	if coding.Status != TaskBlocked || testTask.Status != TaskBlocked {
+		t.Errorf("Expected both tasks to be Blocked, got %s and %s", coding.Status, testTask.Status)
+	}
+	if im.Scope != ScopeMultipleTasks {
+		t.Errorf("Expected Scope to be MultipleTasks, got %s", im.Scope)
+	}
+
+	// Done tasks cannot be blocked
+	done := &DevTask{ID: "done", Status: TaskDone}
+	if err := im.Block(done); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Expected error %v, got %v", ErrIllegalTransition, err)
+	}
+
+	// Resolving restores each task's previous status
+	if err := im.Assign("sm-1", time.Now().Add(24*time.Hour), ResolutionPlan{Immediate: []string{"Restart database"}}); err != nil {
+		t.Fatalf("Failed to assign impediment: %v", err)
+	}
+	if err := im.Resolve("Database restarted", coding, testTask); err != nil {
+		t.Fatalf("Failed to resolve impediment: %v", err)
+	}
+	if coding.Status != TaskInProgress || testTask.Status != TaskTodo {
+		t.Errorf("Expected InProgress and Todo, got %s and %s", coding.Status, testTask.Status)
+	}
+	if _, ok := im.TimeToResolve(); !ok {
+		t.Error("Expected resolved impediment to have a time to resolve")
+	}
+
+	// Resolved impediments can only be reopened
+	if err := im.SetStatus(ImpedimentInProgress); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Expected error %v, got %v", ErrIllegalTransition, err)
+	}
+	if err := im.SetStatus(ImpedimentOpen); err != nil || im.ResolvedAt != nil {
+		t.Errorf("Expected reopening to clear ResolvedAt, got %v and %v", err, im.ResolvedAt)
+	}
+}
+
+func TestSummarizeImpediments(t *testing.T) {
+	now := time.Date(2025, 9, 26, 12, 0, 0, 0, time.UTC)
+	resolvedAt := now.Add(-24 * time.Hour)
+
+	impediments := []*Impediment{
+		{Status: ImpedimentResolved, Urgency: UrgencyLow, ReportedAt: now.Add(-72 * time.Hour), ResolvedAt: &resolvedAt},
+		{Status: ImpedimentResolved, Urgency: UrgencyLow, ReportedAt: now.Add(-48 * time.Hour), ResolvedAt: &resolvedAt},
+		{ID: "old", Status: ImpedimentInProgress, Urgency: UrgencyMedium, ReportedAt: now.Add(-96 * time.Hour)},
+		{ID: "new", Status: ImpedimentOpen, Urgency: UrgencyCritical, ReportedAt: now.Add(-time.Hour)},
+	}
+
+	stats := SummarizeImpediments(impediments, now)
+
+	// Check the counts and the resolution times
+	if stats.Open != 1 || stats.InProgress != 1 || stats.Resolved != 2 {
+		t.Errorf("Expected 1 open, 1 in progress and 2 resolved, got %+v", stats)
+	}
+	if stats.AverageResolve != 36*time.Hour {
+		t.Errorf("Expected average resolve of 36h, got %v", stats.AverageResolve)
+	}
+	if stats.LongestOpen != 96*time.Hour {
+		t.Errorf("Expected longest open of 96h, got %v", stats.LongestOpen)
+	}
+
+	// Open impediments are listed most urgent first
+	open := OpenImpediments(impediments)
+	if len(open) != 2 || open[0].ID != "new" || open[1].ID != "old" {
+		t.Errorf("Expected [new old], got %v", open)
+	}
+}
@@ -0,0 +1,107 @@
+// Release represents a planned product release grouping epics
+package models
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Release represents a planned delivery of one or more epics
+type Release struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Goal       string    `json:"goal"`
+	TargetDate time.Time `json:"target_date"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewRelease creates a new release
+func NewRelease(name, goal string, targetDate time.Time) *Release {
+	return &Release{
+		ID:         generateIDR(),
+		Name:       name,
+		Goal:       goal,
+		TargetDate: targetDate,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// generateIDR generates a unique ID for a release
+func generateIDR() string {
+	return "release-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// Update updates the release with new values
+func (r *Release) Update(name, goal string, targetDate time.Time) {
+	r.Name = name
+	r.Goal = goal
+	r.TargetDate = targetDate
+	r.UpdatedAt = time.Now()
+}
+
+// Epics returns the epics whose parent is this release
+func (r *Release) Epics(epics []*Epic) []*Epic {
+	var children []*Epic
+	for _, e := range epics {
+		if e.ReleaseID == r.ID {
+			children = append(children, e)
+		}
+	}
+	return children
+}
+
+// Stories returns the stories below the release's epics
+func (r *Release) Stories(epics []*Epic, stories []*UserStory) []*UserStory {
+	var children []*UserStory
+	for _, e := range r.Epics(epics) {
+		children = append(children, e.Stories(stories)...)
+	}
+	return children
+}
+
+// Progress rolls up the points and status of all stories below the release
+func (r *Release) Progress(epics []*Epic, stories []*UserStory) Progress {
+	var p Progress
+	for _, us := range r.Stories(epics, stories) {
+		p.add(us)
+	}
+	return p
+}
+
+// BurnUp returns one point per sprint, at its EndDate, with the release scope and the
+// points left after the release stories completed in that sprint and all earlier ones
+func (r *Release) BurnUp(epics []*Epic, stories []*UserStory, sprints []*Sprint) []BurnDownPoint {
+	children := r.Stories(epics, stories)
+	points := StoryPoints(children...)
+	scope := 0
+	inRelease := make(map[string]bool, len(children))
+	for _, us := range children {
+		inRelease[us.ID] = true
+		scope += points(us.ID)
+	}
+
+	ordered := make([]*Sprint, len(sprints))
+	copy(ordered, sprints)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].EndDate.Before(ordered[j].EndDate)
+	})
+
+	var burnUp []BurnDownPoint
+	done := 0
+	counted := make(map[string]bool)
+	for _, s := range ordered {
+		for _, id := range s.Completed {
+			if inRelease[id] && !counted[id] {
+				counted[id] = true
+				done += points(id)
+			}
+		}
+		burnUp = append(burnUp, BurnDownPoint{Date: s.EndDate, Left: scope - done, Scope: scope})
+	}
+
+	return burnUp
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRelease(t *testing.T) {
+	target := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRelease("v1.0", "First public release", target)
+
+	// Check that the release has a valid ID and target date
+	if !startsWith(r.ID, "release-") {
+		t.Errorf("Expected ID to start with 'release-', got %s", r.ID)
+	}
+	if !r.TargetDate.Equal(target) {
+		t.Errorf("Expected TargetDate to be %v, got %v", target, r.TargetDate)
+	}
+}
+
+func TestReleaseProgressAndBurnUp(t *testing.T) {
+	r := NewRelease("v1.0", "First public release", time.Now())
+	accounts := NewEpic("User accounts", "")
+	accounts.SetRelease(r.ID)
+	billing := NewEpic("Billing", "")
+	billing.SetRelease(r.ID)
+	later := NewEpic("Reporting", "")
+
+	login := newEstimatedStory("story-1", 5)
+	login.SetEpic(accounts.ID)
+	invoice := newEstimatedStory("story-2", 3)
+	invoice.SetEpic(billing.ID)
+	report := newEstimatedStory("story-3", 8)
+	report.SetEpic(later.ID)
+
+	epics := []*Epic{accounts, billing, later}
+	stories := []*UserStory{login, invoice, report}
+
+	// Check that the roll-up covers both epics of the release only
+	p := r.Progress(epics, stories)
+	if p.Stories != 2 || p.Points != 8 {
+		t.Errorf("Expected 2 stories and 8 points, got %d and %d", p.Stories, p.Points)
+	}
+
+	// Build two sprints, the later one listed first
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	sprint1 := NewSprint("Sprint 1", start, start.AddDate(0, 0, 14))
+	sprint1.AddCompletedStory("story-1")
+	sprint2 := NewSprint("Sprint 2", start.AddDate(0, 0, 14), start.AddDate(0, 0, 28))
+	sprint2.AddCompletedStory("story-3") // Not part of the release
+	sprint2.AddCompletedStory("story-2")
+
+	burnUp := r.BurnUp(epics, stories, []*Sprint{sprint2, sprint1})
+	if len(burnUp) != 2 {
+		t.Fatalf("Expected 2 burn-up points, got %d", len(burnUp))
+	}
+	if !burnUp[0].Date.Equal(sprint1.EndDate) || burnUp[0].Left != 3 || burnUp[0].Scope != 8 {
+		t.Errorf("Expected 3 of 8 left after sprint 1, got %+v", burnUp[0])
+	}
+	if burnUp[1].Left != 0 {
+		t.Errorf("Expected nothing left after sprint 2, got %d", burnUp[1].Left)
+	}
+}
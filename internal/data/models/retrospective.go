@@ -0,0 +1,158 @@
+// Retrospective represents the retrospective meeting held at the end of a sprint
+package models
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// ActionItemStatus represents the status of a retrospective action item
+type ActionItemStatus string
+
+const (
+	ActionOpen       ActionItemStatus = "Open"
+	ActionInProgress ActionItemStatus = "InProgress"
+	ActionDone       ActionItemStatus = "Done"
+	ActionDropped    ActionItemStatus = "Dropped"
+)
+
+// ActionItem represents an improvement the team commits to in a retrospective
+type ActionItem struct {
+	ID          string           `json:"id"`
+	Description string           `json:"description"`
+	Owner       string           `json:"owner"` // Member ID
+	DueDate     time.Time        `json:"due_date"`
+	Status      ActionItemStatus `json:"status"`
+	OriginID    string           `json:"origin_id"`    // Retrospective the item was raised in
+	CarriedOver int              `json:"carried_over"` // Number of later retrospectives it was carried into
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+}
+
+// SetStatus updates the action item status, recording when it was completed
+func (a *ActionItem) SetStatus(status ActionItemStatus) {
+	a.Status = status
+	if status == ActionDone {
+		now := time.Now()
+		a.CompletedAt = &now
+	} else {
+		a.CompletedAt = nil
+	}
+}
+
+// Finished reports whether the item needs no more follow-up
+func (a *ActionItem) Finished() bool {
+	return a.Status == ActionDone || a.Status == ActionDropped
+}
+
+// Retrospective represents the outcome of a sprint retrospective
+type Retrospective struct {
+	ID          string        `json:"id"`
+	SprintID    string        `json:"sprint_id"`
+	HeldAt      time.Time     `json:"held_at"`
+	Attendees   []string      `json:"attendees"`
+	WentWell    []string      `json:"went_well"`
+	ToImprove   []string      `json:"to_improve"`
+	ActionItems []*ActionItem `json:"action_items"` // Raised here or carried over from earlier retrospectives
+	NextCheck   time.Time     `json:"next_check"`   // When action items are reviewed next
+}
+
+// NewRetrospective creates a new retrospective for a sprint
+func NewRetrospective(sprintID string, attendees ...string) *Retrospective {
+	return &Retrospective{
+		ID:          generateIDRT(),
+		SprintID:    sprintID,
+		HeldAt:      time.Now(),
+		Attendees:   attendees,
+		WentWell:    []string{},
+		ToImprove:   []string{},
+		ActionItems: []*ActionItem{},
+	}
+}
+
+// generateIDRT generates a unique ID for a retrospective
+func generateIDRT() string {
+	return "retro-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// generateIDAI generates a unique ID for an action item
+func generateIDAI() string {
+	return "action-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// AddWentWell records something the team should keep doing
+func (r *Retrospective) AddWentWell(note string) {
+	r.WentWell = append(r.WentWell, note)
+}
+
+// AddToImprove records something the team should change
+func (r *Retrospective) AddToImprove(note string) {
+	r.ToImprove = append(r.ToImprove, note)
+}
+
+// AddActionItem raises a new action item in this retrospective
+func (r *Retrospective) AddActionItem(description, owner string, dueDate time.Time) *ActionItem {
+	item := &ActionItem{
+		ID:          generateIDAI(),
+		Description: description,
+		Owner:       owner,
+		DueDate:     dueDate,
+		Status:      ActionOpen,
+		OriginID:    r.ID,
+	}
+	r.ActionItems = append(r.ActionItems, item)
+	return item
+}
+
+// CarryOver brings the unfinished action items of the previous retrospective into this one.
+// Items are shared rather than copied, so status changes show up in both retrospectives.
+func (r *Retrospective) CarryOver(previous *Retrospective) []*ActionItem {
+	var carried []*ActionItem
+	for _, item := range previous.ActionItems {
+		if item.Finished() || r.hasActionItem(item.ID) {
+			continue
+		}
+		item.CarriedOver++
+		r.ActionItems = append(r.ActionItems, item)
+		carried = append(carried, item)
+	}
+	return carried
+}
+
+// hasActionItem reports whether the retrospective already tracks the item
+func (r *Retrospective) hasActionItem(id string) bool {
+	for _, item := range r.ActionItems {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ImplementationRate returns the share of this retrospective's action items that are done
+func (r *Retrospective) ImplementationRate() float64 {
+	return ImplementationRate(r)
+}
+
+// ImplementationRate returns the share of action items across the retrospectives that are done,
+// counting items carried over between them once. Dropped items count as not implemented.
+func ImplementationRate(retros ...*Retrospective) float64 {
+	seen := make(map[string]bool)
+	total, done := 0, 0
+	for _, r := range retros {
+		for _, item := range r.ActionItems {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			total++
+			if item.Status == ActionDone {
+				done++
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(done) / float64(total)
+}
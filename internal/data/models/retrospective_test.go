@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRetrospective(t *testing.T) {
+	r := NewRetrospective("sprint-1", "dev-1", "sm-1")
+
+	// Check the defaults
+	if !startsWith(r.ID, "retro-") {
+		t.Errorf("Expected ID to start with 'retro-', got %s", r.ID)
+	}
+	if r.SprintID != "sprint-1" || len(r.Attendees) != 2 {
+		t.Errorf("Expected sprint-1 with 2 attendees, got %s with %v", r.SprintID, r.Attendees)
+	}
+
+	// Action items start open and remember where they were raised
+	item := r.AddActionItem("Pair on code reviews", "dev-1", time.Now().AddDate(0, 0, 7))
+	if item.Status != ActionOpen || item.OriginID != r.ID {
+		t.Errorf("Expected an open item from %s, got %s from %s", r.ID, item.Status, item.OriginID)
+	}
+}
+
+func TestRetrospectiveCarryOver(t *testing.T) {
+	first := NewRetrospective("sprint-1")
+	done := first.AddActionItem("Automate deployment", "dev-1", time.Time{})
+	dropped := first.AddActionItem("Daily demo", "po-1", time.Time{})
+	pending := first.AddActionItem("Write ADRs", "dev-2", time.Time{})
+	done.SetStatus(ActionDone)
+	dropped.SetStatus(ActionDropped)
+
+	second := NewRetrospective("sprint-2")
+	second.AddActionItem("Limit WIP to 3", "sm-1", time.Time{})
+	carried := second.CarryOver(first)
+
+	// Only the unfinished item is carried, once
+	if len(carried) != 1 || carried[0] != pending {
+		t.Fatalf("Expected only the pending item to be carried, got %v", carried)
+	}
+	second.CarryOver(first)
+	if len(second.ActionItems) != 2 || pending.CarriedOver != 1 {
+		t.Errorf("Expected 2 items and 1 carry-over, got %d and %d", len(second.ActionItems), pending.CarriedOver)
+	}
+
+	// Completing the shared item shows up in both retrospectives
+	pending.SetStatus(ActionDone)
+	if pending.CompletedAt == nil {
+		t.Error("Expected CompletedAt to be set")
+	}
+	if rate := first.ImplementationRate(); rate != 2.0/3.0 {
+		t.Errorf("Expected first rate to be 2/3, got %v", rate)
+	}
+
+	// Across retrospectives the carried item counts once: 2 done out of 4
+	if rate := ImplementationRate(first, second); rate != 0.5 {
+		t.Errorf("Expected overall rate to be 0.5, got %v", rate)
+	}
+}
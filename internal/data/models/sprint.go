@@ -2,6 +2,7 @@
 package models
 
 import (
+	"encoding/json"
 	"math/rand"
 	"strconv"
 	"time"
@@ -9,20 +10,34 @@ import (
 
 // BurnDownPoint represents a data point in the burn-down chart
 type BurnDownPoint struct {
-	Date time.Time `json:"date"`
-	Left int       `json:"left"` // Remaining story points
+	Date  time.Time `json:"date"`
+	Left  int       `json:"left"`            // Remaining story points
+	Scope int       `json:"scope,omitempty"` // Committed story points on that day
 }
 
+// SprintStatus represents the status of a sprint
+type SprintStatus string
+
+const (
+	SprintPlanned SprintStatus = "Planned"
+	SprintActive  SprintStatus = "Active"
+	SprintClosed  SprintStatus = "Closed"
+)
+
 // Sprint represents an iteration in the agile development process
 type Sprint struct {
 	ID        string          `json:"id"`
 	Goal      string          `json:"goal"`
+	Status    SprintStatus    `json:"status"`
 	StartDate time.Time       `json:"start_date"`
 	EndDate   time.Time       `json:"end_date"`
 	Velocity  int             `json:"velocity"`  // Team velocity
 	Committed []string        `json:"committed"` // IDs of committed user stories
 	Completed []string        `json:"completed"` // IDs of completed user stories
 	BurnDown  []BurnDownPoint `json:"burn_down"` // Burn-down chart data
+
+	recorder Recorder     // Receives mutation events once tracked
+	points   PointsLookup // Drives automatic burn-down snapshots once attached
 }
 
 // generateIDS generates a unique ID for a sprint
@@ -35,6 +50,7 @@ func NewSprint(goal string, startDate, endDate time.Time) *Sprint {
 	return &Sprint{
 		ID:        generateIDS(),
 		Goal:      goal,
+		Status:    SprintPlanned,
 		StartDate: startDate,
 		EndDate:   endDate,
 		Velocity:  0,
@@ -48,6 +64,8 @@ func NewSprint(goal string, startDate, endDate time.Time) *Sprint {
 func (s *Sprint) AddCommittedStory(storyID string) {
 	if !contains(s.Committed, storyID) {
 		s.Committed = append(s.Committed, storyID)
+		emit(s.recorder, KindSprint, s.ID, EventSprintStoryCommitted, valueData[string]{Value: storyID})
+		s.refreshBurnDown()
 	}
 }
 
@@ -56,6 +74,8 @@ func (s *Sprint) RemoveCommittedStory(storyID string) {
 	for i, id := range s.Committed {
 		if id == storyID {
 			s.Committed = append(s.Committed[:i], s.Committed[i+1:]...)
+			emit(s.recorder, KindSprint, s.ID, EventSprintStoryUncommitted, valueData[string]{Value: storyID})
+			s.refreshBurnDown()
 			break
 		}
 	}
@@ -65,12 +85,58 @@ func (s *Sprint) RemoveCommittedStory(storyID string) {
 func (s *Sprint) AddCompletedStory(storyID string) {
 	if !contains(s.Completed, storyID) {
 		s.Completed = append(s.Completed, storyID)
+		emit(s.recorder, KindSprint, s.ID, EventSprintStoryCompleted, valueData[string]{Value: storyID})
+		s.refreshBurnDown()
 	}
 }
 
+// RemoveCompletedStory removes a user story from the completed list, as when a review reopens it
+func (s *Sprint) RemoveCompletedStory(storyID string) {
+	if contains(s.Completed, storyID) {
+		s.Completed = remove(s.Completed, storyID)
+		emit(s.recorder, KindSprint, s.ID, EventSprintStoryReopened, valueData[string]{Value: storyID})
+		s.refreshBurnDown()
+	}
+}
+
+// SetStatus moves the sprint to a new status, enforcing the sprint state machine
+func (s *Sprint) SetStatus(status SprintStatus) error {
+	from := s.Status
+	if status == from {
+		return nil
+	}
+	if err := s.CheckStatus(status); err != nil {
+		return err
+	}
+
+	s.Status = status
+	emit(s.recorder, KindSprint, s.ID, EventSprintStatusSet, valueData[SprintStatus]{Value: status})
+	fireTransition(Transition{Kind: KindSprint, EntityID: s.ID, From: string(from), To: string(status), At: time.Now()})
+
+	return nil
+}
+
+// CheckStatus returns the error SetStatus would return for the status, without changing it
+func (s *Sprint) CheckStatus(status SprintStatus) error {
+	if status == s.Status {
+		return nil
+	}
+	return checkTransition(sprintTransitions, KindSprint, s.ID, s.Status, status, s.sprintGuard(status))
+}
+
 // SetVelocity sets the team velocity
 func (s *Sprint) SetVelocity(velocity int) {
 	s.Velocity = velocity
+	emit(s.recorder, KindSprint, s.ID, EventSprintVelocitySet, valueData[int]{Value: velocity})
+}
+
+// CompletedPoints sums the story points of the completed stories
+func (s *Sprint) CompletedPoints(points PointsLookup) int {
+	total := 0
+	for _, id := range s.Completed {
+		total += points(id)
+	}
+	return total
 }
 
 // AddBurnDownPoint adds a data point to the burn-down chart
@@ -80,6 +146,66 @@ func (s *Sprint) AddBurnDownPoint(date time.Time, left int) {
 		Left: left,
 	}
 	s.BurnDown = append(s.BurnDown, point)
+	emit(s.recorder, KindSprint, s.ID, EventSprintBurnDownAdded, point)
+}
+
+// Track attaches a recorder and records the sprint's current state as its creation event
+func (s *Sprint) Track(r Recorder) {
+	s.recorder = r
+	emit(r, KindSprint, s.ID, EventSprintCreated, s)
+}
+
+// Attach sends the sprint's further events to a recorder without recording its creation again,
+// as when loading it from the log or handing it to another actor
+func (s *Sprint) Attach(r Recorder) {
+	s.recorder = r
+}
+
+// Apply replays a recorded event onto the sprint without emitting new events
+func (s *Sprint) Apply(e Event) error {
+	if e.Type == EventSprintCreated {
+		return json.Unmarshal(e.Data, s)
+	}
+	if e.Type == EventSprintBurnDownAdded || e.Type == EventSprintBurnDownRecorded || e.Type == EventSprintBurnDownRefreshed {
+		var point BurnDownPoint
+		if err := json.Unmarshal(e.Data, &point); err != nil {
+			return err
+		}
+		if e.Type == EventSprintBurnDownAdded {
+			s.BurnDown = append(s.BurnDown, point)
+		} else {
+			s.setBurnDownPoint(point)
+		}
+		return nil
+	}
+	if e.Type == EventSprintStatusSet {
+		status, err := decodeValue[SprintStatus](e)
+		s.Status = status
+		return err
+	}
+	if e.Type == EventSprintVelocitySet {
+		velocity, err := decodeValue[int](e)
+		s.Velocity = velocity
+		return err
+	}
+
+	storyID, err := decodeValue[string](e)
+	if err != nil {
+		return err
+	}
+	switch e.Type {
+	case EventSprintStoryCommitted:
+		s.Committed = append(s.Committed, storyID)
+	case EventSprintStoryUncommitted:
+		s.Committed = remove(s.Committed, storyID)
+	case EventSprintStoryCompleted:
+		s.Completed = append(s.Completed, storyID)
+	case EventSprintStoryReopened:
+		s.Completed = remove(s.Completed, storyID)
+	default:
+		return ErrUnknownEvent
+	}
+	return nil
 }
 
 // contains checks if a slice contains an element
@@ -91,3 +217,13 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// remove returns the slice without the first occurrence of item
+func remove(slice []string, item string) []string {
+	for i, s := range slice {
+		if s == item {
+			return append(slice[:i], slice[i+1:]...)
+		}
+	}
+	return slice
+}
@@ -119,6 +119,35 @@ func TestSprintAddCompletedStory(t *testing.T) {
 	}
 }
 
+func TestSprintRemoveCompletedStory(t *testing.T) {
+	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+	rec := &recorderStub{}
+	s.Track(rec)
+	s.AddCompletedStory("story-1")
+
+	// Reopen the story
+	s.RemoveCompletedStory("story-1")
+
+	// Check that the story was removed and the change recorded
+	if len(s.Completed) != 0 {
+		t.Errorf("Expected Completed to be empty, got %v", s.Completed)
+	}
+	if last := rec.events[len(rec.events)-1]; last.Type != EventSprintStoryReopened {
+		t.Errorf("Expected last event to be %s, got %s", EventSprintStoryReopened, last.Type)
+	}
+
+	// Replaying the events restores the same state
+	replayed := &Sprint{}
+	for _, e := range rec.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply %s: %v", e.Type, err)
+		}
+	}
+	if len(replayed.Completed) != 0 {
+		t.Errorf("Expected replayed Completed to be empty, got %v", replayed.Completed)
+	}
+}
+
 func TestSprintSetVelocity(t *testing.T) {
 	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
 
@@ -165,3 +194,16 @@ func TestSprintContains(t *testing.T) {
 		t.Errorf("Expected contains to return true for existing story")
 	}
 }
+
+func TestSprintCompletedPoints(t *testing.T) {
+	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+	s.AddCommittedStory("story-1")
+	s.AddCommittedStory("story-2")
+	s.AddCompletedStory("story-1")
+
+	// Only completed stories count towards velocity
+	points := StoryPoints(newEstimatedStory("story-1", 5), newEstimatedStory("story-2", 3))
+	if got := s.CompletedPoints(points); got != 5 {
+		t.Errorf("Expected 5 completed points, got %d", got)
+	}
+}
@@ -0,0 +1,278 @@
+// TaskGraph represents the dependency graph over the tasks of a sprint
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrUnknownTask is returned when a task ID is not part of the graph
+var ErrUnknownTask = modelError("unknown task")
+
+// ErrDanglingDependency is returned when a dependency refers to a task outside the graph
+var ErrDanglingDependency = modelError("dangling dependency")
+
+// ErrDependencyCycle is returned when a dependency would make the graph cyclic
+var ErrDependencyCycle = modelError("dependency cycle")
+
+// TaskGraph is a directed acyclic graph of tasks, with edges from a task to its dependencies
+type TaskGraph struct {
+	tasks map[string]*DevTask
+	order []string // Insertion order, keeps results deterministic
+}
+
+// NewTaskGraph creates a graph from the given tasks, validating their existing dependencies
+func NewTaskGraph(tasks ...*DevTask) (*TaskGraph, error) {
+	g := &TaskGraph{tasks: make(map[string]*DevTask)}
+	for _, task := range tasks {
+		if _, ok := g.tasks[task.ID]; !ok {
+			g.order = append(g.order, task.ID)
+		}
+		g.tasks[task.ID] = task
+	}
+
+	for _, id := range g.order {
+		task, ok := g.tasks[id]
+		if !ok {
+			continue
+		}
+		for _, dep := range task.Dependencies {
+			if _, ok := g.tasks[dep]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrDanglingDependency, id, dep)
+			}
+		}
+	}
+	if _, err := g.TopologicalOrder(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Add inserts a task whose dependencies are already in the graph, or replaces the task
+// with the same ID, rejecting dependencies that would close a cycle
+func (g *TaskGraph) Add(task *DevTask) error {
+	for _, dep := range task.Dependencies {
+		if _, ok := g.tasks[dep]; !ok {
+			return fmt.Errorf("%w: %s depends on %s", ErrDanglingDependency, task.ID, dep)
+		}
+		if dep == task.ID || g.reaches(dep, task.ID) {
+			return fmt.Errorf("%w: %s -> %s", ErrDependencyCycle, task.ID, dep)
+		}
+	}
+	if _, ok := g.tasks[task.ID]; !ok {
+		g.order = append(g.order, task.ID)
+	}
+	g.tasks[task.ID] = task
+	return nil
+}
+
+// Task returns the task with the given ID
+func (g *TaskGraph) Task(id string) (*DevTask, bool) {
+	task, ok := g.tasks[id]
+	return task, ok
+}
+
+// Tasks returns all tasks in insertion order
+func (g *TaskGraph) Tasks() []*DevTask {
+	tasks := make([]*DevTask, 0, len(g.order))
+	for _, id := range g.order {
+		tasks = append(tasks, g.tasks[id])
+	}
+	return tasks
+}
+
+// AddDependency makes taskID depend on dependencyID, rejecting dangling IDs and cycles
+func (g *TaskGraph) AddDependency(taskID, dependencyID string) error {
+	task, ok := g.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTask, taskID)
+	}
+	if _, ok := g.tasks[dependencyID]; !ok {
+		return fmt.Errorf("%w: %s depends on %s", ErrDanglingDependency, taskID, dependencyID)
+	}
+
+	// The new edge closes a cycle if the dependency already reaches the task
+	if taskID == dependencyID || g.reaches(dependencyID, taskID) {
+		return fmt.Errorf("%w: %s -> %s", ErrDependencyCycle, taskID, dependencyID)
+	}
+
+	task.AddDependency(dependencyID)
+	return nil
+}
+
+// reaches reports whether target is reachable from start by following dependencies
+func (g *TaskGraph) reaches(start, target string) bool {
+	visited := make(map[string]bool)
+	stack := []string{start}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == target {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		task, ok := g.tasks[id]
+		if !ok {
+			continue // Dependency added on the task itself, outside the graph
+		}
+		stack = append(stack, task.Dependencies...)
+	}
+	return false
+}
+
+// Dependencies returns the tasks the given task depends on
+func (g *TaskGraph) Dependencies(taskID string) []*DevTask {
+	task, ok := g.tasks[taskID]
+	if !ok {
+		return nil
+	}
+	deps := make([]*DevTask, 0, len(task.Dependencies))
+	for _, id := range task.Dependencies {
+		if dep, ok := g.tasks[id]; ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// SetStatus moves a task to a new status, passing its dependencies to the task's guard
+func (g *TaskGraph) SetStatus(taskID string, status TaskStatus) error {
+	task, ok := g.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTask, taskID)
+	}
+	return task.SetStatus(status, g.Dependencies(taskID)...)
+}
+
+// TopologicalOrder returns the tasks ordered so that every task follows its dependencies
+func (g *TaskGraph) TopologicalOrder() ([]*DevTask, error) {
+	// Count unresolved dependencies and collect the reverse edges
+	pending := make(map[string]int, len(g.order))
+	dependents := make(map[string][]string, len(g.order))
+	for _, id := range g.order {
+		task, ok := g.tasks[id]
+		if !ok {
+			continue
+		}
+		for _, dep := range task.Dependencies {
+			if _, ok := g.tasks[dep]; !ok {
+				continue
+			}
+			pending[id]++
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+
+	var queue []string
+	for _, id := range g.order {
+		if pending[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+
+	sorted := make([]*DevTask, 0, len(g.order))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, g.tasks[id])
+		for _, next := range dependents[id] {
+			pending[next]--
+			if pending[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if len(sorted) != len(g.order) {
+		return nil, ErrDependencyCycle
+	}
+	return sorted, nil
+}
+
+// Ready returns the Todo tasks whose dependencies are all Done
+func (g *TaskGraph) Ready() []*DevTask {
+	var ready []*DevTask
+	for _, id := range g.order {
+		task := g.tasks[id]
+		if task.Status != TaskTodo {
+			continue
+		}
+		blocked := false
+		for _, dep := range g.Dependencies(id) {
+			if dep.Status != TaskDone {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			ready = append(ready, task)
+		}
+	}
+	return ready
+}
+
+// CriticalPath returns the longest chain of remaining work by Estimate and its total duration.
+// Done tasks contribute no duration.
+func (g *TaskGraph) CriticalPath() ([]*DevTask, time.Duration) {
+	sorted, err := g.TopologicalOrder()
+	if err != nil || len(sorted) == 0 {
+		return nil, 0
+	}
+
+	// finish holds the longest duration ending at each task, prev the predecessor on that path
+	finish := make(map[string]time.Duration, len(sorted))
+	prev := make(map[string]string, len(sorted))
+	var last string
+	for _, task := range sorted {
+		var start time.Duration
+		for _, dep := range g.Dependencies(task.ID) {
+			if finish[dep.ID] > start || prev[task.ID] == "" {
+				start = finish[dep.ID]
+				prev[task.ID] = dep.ID
+			}
+		}
+		finish[task.ID] = start + remaining(task)
+		if last == "" || finish[task.ID] > finish[last] {
+			last = task.ID
+		}
+	}
+
+	var path []*DevTask
+	for id := last; id != ""; id = prev[id] {
+		path = append([]*DevTask{g.tasks[id]}, path...)
+	}
+	return path, finish[last]
+}
+
+// remaining returns the work left on a task
+func remaining(task *DevTask) time.Duration {
+	if task.Status == TaskDone {
+		return 0
+	}
+	return task.Estimate
+}
+
+// StoryDependencies derives story-level dependencies from task dependencies:
+// a story depends on another when one of its tasks depends on one of the other's tasks
+func StoryDependencies(tasks []*DevTask) map[string][]string {
+	storyOf := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		storyOf[task.ID] = task.StoryID
+	}
+
+	deps := make(map[string][]string)
+	for _, task := range tasks {
+		for _, depID := range task.Dependencies {
+			other, ok := storyOf[depID]
+			if !ok || other == task.StoryID || contains(deps[task.StoryID], other) {
+				continue
+			}
+			deps[task.StoryID] = append(deps[task.StoryID], other)
+		}
+	}
+	return deps
+}
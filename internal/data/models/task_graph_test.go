@@ -0,0 +1,191 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestGraph builds design -> api -> ui and design -> tests, with tests depending on api too
+func newTestGraph(t *testing.T) (*TaskGraph, map[string]*DevTask) {
+	tasks := map[string]*DevTask{
+		"design": {ID: "design", Title: "Design", Estimate: 2 * time.Hour, Status: TaskTodo},
+		"api":    {ID: "api", Title: "API", Estimate: 8 * time.Hour, Status: TaskTodo},
+		"ui":     {ID: "ui", Title: "UI", Estimate: 3 * time.Hour, Status: TaskTodo},
+		"tests":  {ID: "tests", Title: "Tests", Estimate: 4 * time.Hour, Status: TaskTodo},
+	}
+
+	g, err := NewTaskGraph(tasks["design"], tasks["api"], tasks["ui"], tasks["tests"])
+	if err != nil {
+		t.Fatalf("Failed to create graph: %v", err)
+	}
+	for _, edge := range [][2]string{{"api", "design"}, {"ui", "api"}, {"tests", "design"}, {"tests", "api"}} {
+		if err := g.AddDependency(edge[0], edge[1]); err != nil {
+			t.Fatalf("Failed to add dependency %s -> %s: %v", edge[0], edge[1], err)
+		}
+	}
+	return g, tasks
+}
+
+func TestNewTaskGraphRejectsDanglingDependency(t *testing.T) {
+	dt := NewDevTask("story-1", "Implement login feature", "dev-1")
+	dt.AddDependency("task-missing")
+
+	_, err := NewTaskGraph(dt)
+	if !errors.Is(err, ErrDanglingDependency) {
+		t.Errorf("Expected error %v, got %v", ErrDanglingDependency, err)
+	}
+}
+
+func TestNewTaskGraphRejectsCycle(t *testing.T) {
+	a := &DevTask{ID: "a", Dependencies: []string{"b"}}
+	b := &DevTask{ID: "b", Dependencies: []string{"a"}}
+
+	_, err := NewTaskGraph(a, b)
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("Expected error %v, got %v", ErrDependencyCycle, err)
+	}
+}
+
+func TestTaskGraphAddDependency(t *testing.T) {
+	g, tasks := newTestGraph(t)
+
+	// A dependency closing a cycle is rejected and not recorded
+	if err := g.AddDependency("design", "ui"); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("Expected error %v, got %v", ErrDependencyCycle, err)
+	}
+	if len(tasks["design"].Dependencies) != 0 {
+		t.Errorf("Expected design to have no dependencies, got %v", tasks["design"].Dependencies)
+	}
+
+	// Self dependencies are cycles too
+	if err := g.AddDependency("ui", "ui"); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("Expected error %v, got %v", ErrDependencyCycle, err)
+	}
+
+	// Dangling and unknown IDs are rejected
+	if err := g.AddDependency("ui", "missing"); !errors.Is(err, ErrDanglingDependency) {
+		t.Errorf("Expected error %v, got %v", ErrDanglingDependency, err)
+	}
+	if err := g.AddDependency("missing", "ui"); !errors.Is(err, ErrUnknownTask) {
+		t.Errorf("Expected error %v, got %v", ErrUnknownTask, err)
+	}
+}
+
+func TestTaskGraphOutsideDependency(t *testing.T) {
+	g, tasks := newTestGraph(t)
+
+	// A dependency added on the task itself points outside the graph and must not panic
+	tasks["design"].AddDependency("task-elsewhere")
+	if err := g.AddDependency("ui", "tests"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := g.TopologicalOrder(); err != nil {
+		t.Errorf("Expected an order, got %v", err)
+	}
+}
+
+func TestTaskGraphAddReplaces(t *testing.T) {
+	g, _ := newTestGraph(t)
+
+	// Replacing design with a version depending on ui closes a cycle and is rejected
+	changed := &DevTask{ID: "design", Dependencies: []string{"ui"}}
+	if err := g.Add(changed); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("Expected error %v, got %v", ErrDependencyCycle, err)
+	}
+	if task, _ := g.Task("design"); task == changed {
+		t.Errorf("Expected the original design task to stay in the graph")
+	}
+
+	// Replacing it without new dependencies is accepted
+	if err := g.Add(&DevTask{ID: "design", Title: "Design v2"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if task, _ := g.Task("design"); task.Title != "Design v2" {
+		t.Errorf("Expected the replaced task, got %q", task.Title)
+	}
+}
+
+func TestTaskGraphTopologicalOrder(t *testing.T) {
+	g, _ := newTestGraph(t)
+
+	sorted, err := g.TopologicalOrder()
+	if err != nil {
+		t.Fatalf("Failed to sort graph: %v", err)
+	}
+
+	// Check that every task comes after its dependencies
+	position := make(map[string]int)
+	for i, task := range sorted {
+		position[task.ID] = i
+	}
+	for _, task := range sorted {
+		for _, dep := range task.Dependencies {
+			if position[dep] > position[task.ID] {
+				t.Errorf("Expected %s before %s, got order %v", dep, task.ID, position)
+			}
+		}
+	}
+}
+
+func TestTaskGraphReady(t *testing.T) {
+	g, tasks := newTestGraph(t)
+
+	// Only the task without dependencies is ready at first
+	ready := g.Ready()
+	if len(ready) != 1 || ready[0].ID != "design" {
+		t.Fatalf("Expected only design to be ready, got %v", ready)
+	}
+
+	// Finishing design and api unblocks ui and tests in parallel
+	for _, id := range []string{"design", "api"} {
+		if err := g.SetStatus(id, TaskInProgress); err != nil {
+			t.Fatalf("Failed to start %s: %v", id, err)
+		}
+		if err := g.SetStatus(id, TaskDone); err != nil {
+			t.Fatalf("Failed to finish %s: %v", id, err)
+		}
+	}
+	ready = g.Ready()
+	if len(ready) != 2 || ready[0] != tasks["ui"] || ready[1] != tasks["tests"] {
+		t.Errorf("Expected ui and tests to be ready, got %v", ready)
+	}
+}
+
+func TestTaskGraphCriticalPath(t *testing.T) {
+	g, _ := newTestGraph(t)
+
+	// design (2h) -> api (8h) -> tests (4h) is longer than via ui (3h)
+	path, total := g.CriticalPath()
+	if total != 14*time.Hour {
+		t.Errorf("Expected critical path of 14h, got %v", total)
+	}
+	ids := make([]string, 0, len(path))
+	for _, task := range path {
+		ids = append(ids, task.ID)
+	}
+	if len(ids) != 3 || ids[0] != "design" || ids[1] != "api" || ids[2] != "tests" {
+		t.Errorf("Expected path [design api tests], got %v", ids)
+	}
+
+	// Completed work no longer counts
+	g.SetStatus("design", TaskInProgress)
+	g.SetStatus("design", TaskDone)
+	if _, total := g.CriticalPath(); total != 12*time.Hour {
+		t.Errorf("Expected critical path of 12h after finishing design, got %v", total)
+	}
+}
+
+func TestStoryDependencies(t *testing.T) {
+	tasks := []*DevTask{
+		{ID: "a1", StoryID: "story-a"},
+		{ID: "a2", StoryID: "story-a", Dependencies: []string{"a1"}},
+		{ID: "b1", StoryID: "story-b", Dependencies: []string{"a2", "a1", "missing"}},
+	}
+
+	// Check that only cross-story edges are kept, once each
+	deps := StoryDependencies(tasks)
+	if len(deps) != 1 || len(deps["story-b"]) != 1 || deps["story-b"][0] != "story-a" {
+		t.Errorf("Expected story-b to depend on story-a only, got %v", deps)
+	}
+}
@@ -0,0 +1,229 @@
+// Team represents the development team and its members' capacity
+package models
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// Role represents the role of a team member
+type Role string
+
+const (
+	RoleDeveloper    Role = "Developer"
+	RoleTester       Role = "Tester"
+	RoleProductOwner Role = "ProductOwner"
+	RoleScrumMaster  Role = "ScrumMaster"
+)
+
+// Member represents a person (or agent) on the team
+type Member struct {
+	ID           string                         `json:"id"`
+	Name         string                         `json:"name"`
+	Role         Role                           `json:"role"`
+	Skills       []string                       `json:"skills"`       // Task types and technologies, e.g. "Testing", "backend"
+	Availability map[time.Weekday]time.Duration `json:"availability"` // Working hours per weekday
+	Holidays     []time.Time                    `json:"holidays"`
+	FocusFactor  float64                        `json:"focus_factor"` // Share of working hours spent on sprint tasks, 0-1
+}
+
+// NewMember creates a member working eight hours Monday to Friday with a focus factor of 0.7
+func NewMember(name string, role Role) *Member {
+	availability := make(map[time.Weekday]time.Duration)
+	for day := time.Monday; day <= time.Friday; day++ {
+		availability[day] = 8 * time.Hour
+	}
+
+	return &Member{
+		ID:           generateIDM(),
+		Name:         name,
+		Role:         role,
+		Skills:       []string{},
+		Availability: availability,
+		Holidays:     []time.Time{},
+		FocusFactor:  0.7,
+	}
+}
+
+// generateIDM generates a unique ID for a team member
+func generateIDM() string {
+	return "member-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// AddSkill adds a skill, preventing duplicates
+func (m *Member) AddSkill(skill string) {
+	if !contains(m.Skills, skill) {
+		m.Skills = append(m.Skills, skill)
+	}
+}
+
+// HasSkill reports whether the member has the given skill
+func (m *Member) HasSkill(skill string) bool {
+	return contains(m.Skills, skill)
+}
+
+// AddHoliday marks a day off
+func (m *Member) AddHoliday(day time.Time) {
+	m.Holidays = append(m.Holidays, truncateDay(day))
+}
+
+// HoursOn returns the working hours on the given day, zero on holidays
+func (m *Member) HoursOn(day time.Time) time.Duration {
+	if onDay(m.Holidays, day) {
+		return 0
+	}
+	return m.Availability[day.Weekday()]
+}
+
+// Capacity returns the focused working time between start and end, both days inclusive
+func (m *Member) Capacity(start, end time.Time) time.Duration {
+	return m.capacity(start, end, nil)
+}
+
+// capacity sums focused hours, skipping the member's and the given extra holidays
+func (m *Member) capacity(start, end time.Time, holidays []time.Time) time.Duration {
+	var total time.Duration
+	for day := truncateDay(start); !day.After(truncateDay(end)); day = day.AddDate(0, 0, 1) {
+		if onDay(holidays, day) {
+			continue
+		}
+		total += m.HoursOn(day)
+	}
+	return time.Duration(float64(total) * m.FocusFactor)
+}
+
+// onDay reports whether any of the dates falls on the given day
+func onDay(dates []time.Time, day time.Time) bool {
+	day = truncateDay(day)
+	for _, d := range dates {
+		if truncateDay(d).Equal(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// Team represents the development team
+type Team struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Members  []*Member   `json:"members"`
+	Holidays []time.Time `json:"holidays"` // Team-wide days off
+}
+
+// NewTeam creates an empty team
+func NewTeam(name string) *Team {
+	return &Team{
+		ID:       generateIDT(),
+		Name:     name,
+		Members:  []*Member{},
+		Holidays: []time.Time{},
+	}
+}
+
+// generateIDT generates a unique ID for a team
+func generateIDT() string {
+	return "team-" + time.Now().Format("20060102150405.000000") + "." + strconv.FormatInt(rand.Int63(), 10)
+}
+
+// AddMember adds a member, preventing duplicates
+func (t *Team) AddMember(m *Member) {
+	if _, ok := t.Member(m.ID); !ok {
+		t.Members = append(t.Members, m)
+	}
+}
+
+// RemoveMember removes a member
+func (t *Team) RemoveMember(memberID string) {
+	for i, m := range t.Members {
+		if m.ID == memberID {
+			t.Members = append(t.Members[:i], t.Members[i+1:]...)
+			break
+		}
+	}
+}
+
+// Member returns the member with the given ID
+func (t *Team) Member(memberID string) (*Member, bool) {
+	for _, m := range t.Members {
+		if m.ID == memberID {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// AddHoliday marks a team-wide day off
+func (t *Team) AddHoliday(day time.Time) {
+	t.Holidays = append(t.Holidays, truncateDay(day))
+}
+
+// Capacity represents the available work of a team or member over a sprint
+type Capacity struct {
+	Hours    time.Duration            `json:"hours"`
+	Points   int                      `json:"points"`
+	ByMember map[string]time.Duration `json:"by_member"`
+}
+
+// MemberCapacity returns the focused working time of one member over the sprint
+func (t *Team) MemberCapacity(m *Member, s *Sprint) time.Duration {
+	return m.capacity(s.StartDate, s.EndDate, t.Holidays)
+}
+
+// Capacity returns the team's focused hours over the sprint and their value in story points,
+// using hoursPerPoint as the conversion rate; a non-positive rate yields zero points
+func (t *Team) Capacity(s *Sprint, hoursPerPoint float64) Capacity {
+	c := Capacity{ByMember: make(map[string]time.Duration, len(t.Members))}
+	for _, m := range t.Members {
+		hours := t.MemberCapacity(m, s)
+		c.ByMember[m.ID] = hours
+		c.Hours += hours
+	}
+	if hoursPerPoint > 0 {
+		c.Points = int(c.Hours.Hours() / hoursPerPoint)
+	}
+	return c
+}
+
+// CapacityWarning reports committed work exceeding available capacity
+type CapacityWarning struct {
+	MemberID  string        `json:"member_id,omitempty"` // Empty for the whole team
+	Committed time.Duration `json:"committed"`
+	Available time.Duration `json:"available"`
+}
+
+// Overload returns how much committed work exceeds capacity
+func (w CapacityWarning) Overload() time.Duration {
+	return w.Committed - w.Available
+}
+
+// CheckCapacity compares the estimates of the tasks belonging to the sprint's committed
+// stories against the team's capacity, overall and per assignee
+func (t *Team) CheckCapacity(s *Sprint, tasks []*DevTask) []CapacityWarning {
+	capacity := t.Capacity(s, 0)
+
+	var total time.Duration
+	byAssignee := make(map[string]time.Duration)
+	for _, task := range tasks {
+		if !contains(s.Committed, task.StoryID) {
+			continue
+		}
+		total += task.Estimate
+		if task.Assignee != "" {
+			byAssignee[task.Assignee] += task.Estimate
+		}
+	}
+
+	var warnings []CapacityWarning
+	if total > capacity.Hours {
+		warnings = append(warnings, CapacityWarning{Committed: total, Available: capacity.Hours})
+	}
+	for _, m := range t.Members {
+		if committed := byAssignee[m.ID]; committed > capacity.ByMember[m.ID] {
+			warnings = append(warnings, CapacityWarning{MemberID: m.ID, Committed: committed, Available: capacity.ByMember[m.ID]})
+		}
+	}
+
+	return warnings
+}
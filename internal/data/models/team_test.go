@@ -0,0 +1,122 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestWeek returns a sprint running Monday 2025-09-22 to Sunday 2025-09-28
+func newTestWeek() *Sprint {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	return NewSprint("Implement user authentication", start, start.AddDate(0, 0, 6))
+}
+
+func TestNewMember(t *testing.T) {
+	m := NewMember("Alice", RoleDeveloper)
+
+	// Check the defaults
+	if !startsWith(m.ID, "member-") {
+		t.Errorf("Expected ID to start with 'member-', got %s", m.ID)
+	}
+	if m.FocusFactor != 0.7 {
+		t.Errorf("Expected FocusFactor to be 0.7, got %v", m.FocusFactor)
+	}
+	if m.Availability[time.Monday] != 8*time.Hour || m.Availability[time.Saturday] != 0 {
+		t.Errorf("Expected 8h on weekdays and none at weekends, got %v", m.Availability)
+	}
+
+	// Skills are not duplicated
+	m.AddSkill("Testing")
+	m.AddSkill("Testing")
+	if len(m.Skills) != 1 || !m.HasSkill("Testing") {
+		t.Errorf("Expected Skills to be [Testing], got %v", m.Skills)
+	}
+}
+
+func TestMemberCapacity(t *testing.T) {
+	s := newTestWeek()
+	m := NewMember("Alice", RoleDeveloper)
+	m.FocusFactor = 0.5
+
+	// Five working days of 8h at 50% focus
+	if c := m.Capacity(s.StartDate, s.EndDate); c != 20*time.Hour {
+		t.Errorf("Expected 20h capacity, got %v", c)
+	}
+
+	// A holiday removes a whole day
+	m.AddHoliday(s.StartDate.Add(10 * time.Hour))
+	if c := m.Capacity(s.StartDate, s.EndDate); c != 16*time.Hour {
+		t.Errorf("Expected 16h capacity after a holiday, got %v", c)
+	}
+	if h := m.HoursOn(s.StartDate); h != 0 {
+		t.Errorf("Expected no hours on a holiday, got %v", h)
+	}
+}
+
+func TestTeamCapacity(t *testing.T) {
+	s := newTestWeek()
+	team := NewTeam("Core")
+	alice := NewMember("Alice", RoleDeveloper)
+	bob := NewMember("Bob", RoleTester)
+	bob.Availability[time.Friday] = 4 * time.Hour
+	team.AddMember(alice)
+	team.AddMember(bob)
+	team.AddMember(alice)
+
+	if len(team.Members) != 2 {
+		t.Fatalf("Expected 2 members, got %d", len(team.Members))
+	}
+
+	// A team holiday applies to everyone
+	team.AddHoliday(s.StartDate)
+
+	// Alice: 4 days * 8h, Bob: 3 days * 8h + 4h, both at 70% focus
+	c := team.Capacity(s, 4)
+	expected := time.Duration(float64(60*time.Hour) * 0.7)
+	if c.Hours != expected {
+		t.Errorf("Expected %v capacity, got %v", expected, c.Hours)
+	}
+	if c.Points != 10 {
+		t.Errorf("Expected 10 points at 4h per point, got %d", c.Points)
+	}
+	if c.ByMember[alice.ID] != time.Duration(float64(32*time.Hour)*0.7) {
+		t.Errorf("Expected Alice to have %v, got %v", time.Duration(float64(32*time.Hour)*0.7), c.ByMember[alice.ID])
+	}
+
+	team.RemoveMember(bob.ID)
+	if _, ok := team.Member(bob.ID); ok {
+		t.Error("Expected Bob to be removed")
+	}
+}
+
+func TestTeamCheckCapacity(t *testing.T) {
+	s := newTestWeek()
+	s.AddCommittedStory("story-1")
+	team := NewTeam("Core")
+	alice := NewMember("Alice", RoleDeveloper)
+	alice.FocusFactor = 0.5
+	team.AddMember(alice)
+
+	// 20h available; 24h committed to Alice, plus work on an uncommitted story
+	tasks := []*DevTask{
+		{ID: "task-1", StoryID: "story-1", Estimate: 16 * time.Hour, Assignee: alice.ID},
+		{ID: "task-2", StoryID: "story-1", Estimate: 8 * time.Hour, Assignee: alice.ID},
+		{ID: "task-3", StoryID: "story-2", Estimate: 40 * time.Hour, Assignee: alice.ID},
+	}
+
+	warnings := team.CheckCapacity(s, tasks)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected a team and a member warning, got %v", warnings)
+	}
+	if warnings[0].MemberID != "" || warnings[0].Overload() != 4*time.Hour {
+		t.Errorf("Expected the team to be overloaded by 4h, got %+v", warnings[0])
+	}
+	if warnings[1].MemberID != alice.ID {
+		t.Errorf("Expected a warning for Alice, got %+v", warnings[1])
+	}
+
+	// Within capacity there is nothing to report
+	if warnings := team.CheckCapacity(s, tasks[1:]); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
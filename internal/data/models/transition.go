@@ -0,0 +1,221 @@
+// Transition tables and guards for the status state machines of stories, tasks and sprints
+package models
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrIllegalTransition is returned when the target status is not reachable from the current one
+var ErrIllegalTransition = modelError("illegal status transition")
+
+// ErrGuardFailed is returned when a transition is allowed by the table but its guard rejects it
+var ErrGuardFailed = modelError("transition guard failed")
+
+// TransitionError describes a rejected status change
+type TransitionError struct {
+	Kind     EntityKind
+	EntityID string
+	From     string // Empty for entities created in the status they failed to enter
+	To       string
+	Reason   string // Empty for transitions missing from the table
+	err      error
+}
+
+func (e *TransitionError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s -> %s: %v", e.Kind, e.EntityID, e.From, e.To, e.err)
+	if e.From == "" {
+		msg = fmt.Sprintf("%s %s: %s: %v", e.Kind, e.EntityID, e.To, e.err)
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// Unwrap returns ErrIllegalTransition or ErrGuardFailed
+func (e *TransitionError) Unwrap() error {
+	return e.err
+}
+
+// Transition describes a successful status change, passed to transition hooks
+type Transition struct {
+	Kind     EntityKind
+	EntityID string
+	From     string // Empty for entities created in the status they failed to enter
+	To       string
+	At       time.Time
+}
+
+// TransitionHook is called after every successful status change
+type TransitionHook func(Transition)
+
+var (
+	hooksMutex sync.RWMutex
+	hooks      = make(map[int]TransitionHook)
+	nextHookID int
+)
+
+// OnTransition registers a hook fired on every status transition and returns a function removing it
+func OnTransition(hook TransitionHook) func() {
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+
+	id := nextHookID
+	nextHookID++
+	hooks[id] = hook
+
+	return func() {
+		hooksMutex.Lock()
+		defer hooksMutex.Unlock()
+		delete(hooks, id)
+	}
+}
+
+// fireTransition calls all registered hooks
+func fireTransition(t Transition) {
+	hooksMutex.RLock()
+	registered := make([]TransitionHook, 0, len(hooks))
+	for _, hook := range hooks {
+		registered = append(registered, hook)
+	}
+	hooksMutex.RUnlock()
+
+	for _, hook := range registered {
+		hook(t)
+	}
+}
+
+// storyTransitions lists the statuses reachable from each story status
+var storyTransitions = map[StoryStatus][]StoryStatus{
+	StoryDraft:      {StoryReady},
+	StoryReady:      {StoryDraft, StoryInProgress},
+	StoryInProgress: {StoryReady, StoryDone},
+	StoryDone:       {StoryInProgress}, // Reopened after a rejected review
+}
+
+// taskTransitions lists the statuses reachable from each task status
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskTodo:       {TaskInProgress, TaskBlocked},
+	TaskInProgress: {TaskTodo, TaskDone, TaskBlocked},
+	TaskBlocked:    {TaskTodo, TaskInProgress},
+	TaskDone:       {TaskInProgress}, // Reopened after a failed check
+}
+
+// sprintTransitions lists the statuses reachable from each sprint status
+var sprintTransitions = map[SprintStatus][]SprintStatus{
+	SprintPlanned: {SprintActive},
+	SprintActive:  {SprintClosed},
+	SprintClosed:  {},
+}
+
+// impedimentTransitions lists the statuses reachable from each impediment status
+var impedimentTransitions = map[ImpedimentStatus][]ImpedimentStatus{
+	ImpedimentOpen:       {ImpedimentInProgress, ImpedimentResolved},
+	ImpedimentInProgress: {ImpedimentOpen, ImpedimentResolved},
+	ImpedimentResolved:   {ImpedimentOpen}, // Reopened when the obstacle returns
+}
+
+// allowed reports whether the table permits moving from one status to another
+func allowed[S ~string](table map[S][]S, from, to S) bool {
+	for _, s := range table[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransition validates a status change against a table and an optional guard reason
+func checkTransition[S ~string](table map[S][]S, kind EntityKind, id string, from, to S, guard func() string) error {
+	if !allowed(table, from, to) {
+		return &TransitionError{Kind: kind, EntityID: id, From: string(from), To: string(to), err: ErrIllegalTransition}
+	}
+	if guard == nil {
+		return nil
+	}
+	if reason := guard(); reason != "" {
+		return &TransitionError{Kind: kind, EntityID: id, From: string(from), To: string(to), Reason: reason, err: ErrGuardFailed}
+	}
+	return nil
+}
+
+// checkEntry validates an entity already in a status against the guard of that status
+func checkEntry[S ~string](kind EntityKind, id string, status S, guard func() string) error {
+	if guard == nil {
+		return nil
+	}
+	if reason := guard(); reason != "" {
+		return &TransitionError{Kind: kind, EntityID: id, To: string(status), Reason: reason, err: ErrGuardFailed}
+	}
+	return nil
+}
+
+// CheckEntry checks that a story created in its status, as on import, meets the entry
+// conditions of that status
+func (us *UserStory) CheckEntry() error {
+	return checkEntry(KindStory, us.ID, us.Status, us.storyGuard(us.Status))
+}
+
+// CheckEntry checks that a task created in its status meets the entry conditions of that
+// status, given its dependencies
+func (dt *DevTask) CheckEntry(dependencies ...*DevTask) error {
+	return checkEntry(KindTask, dt.ID, dt.Status, dt.taskGuard(dt.Status, dependencies))
+}
+
+// CheckEntry checks that a sprint created in its status meets the entry conditions of that
+// status
+func (s *Sprint) CheckEntry() error {
+	return checkEntry(KindSprint, s.ID, s.Status, s.sprintGuard(s.Status))
+}
+
+// storyGuard enforces the entry conditions of story statuses
+func (us *UserStory) storyGuard(to StoryStatus) func() string {
+	if to != StoryReady {
+		return nil
+	}
+	return func() string {
+		if len(us.AcceptanceCriteria) == 0 {
+			return "ready requires acceptance criteria"
+		}
+		if us.Estimate == nil || us.Estimate.Points <= 0 {
+			return "ready requires an estimate"
+		}
+		return ""
+	}
+}
+
+// taskGuard enforces the entry conditions of task statuses
+func (dt *DevTask) taskGuard(to TaskStatus, dependencies []*DevTask) func() string {
+	if to != TaskDone {
+		return nil
+	}
+	return func() string {
+		done := make(map[string]bool, len(dependencies))
+		for _, dep := range dependencies {
+			if dep != nil && dep.Status == TaskDone {
+				done[dep.ID] = true
+			}
+		}
+		for _, id := range dt.Dependencies {
+			if !done[id] {
+				return "done requires dependency " + id + " to be done"
+			}
+		}
+		return ""
+	}
+}
+
+// sprintGuard enforces the entry conditions of sprint statuses
+func (s *Sprint) sprintGuard(to SprintStatus) func() string {
+	if to != SprintActive {
+		return nil
+	}
+	return func() string {
+		if len(s.Committed) == 0 {
+			return "active requires committed stories"
+		}
+		return ""
+	}
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserStoryIllegalTransition(t *testing.T) {
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+
+	// Draft cannot jump straight to Done
+	err := us.SetStatus(StoryDone)
+	if !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Expected error %v, got %v", ErrIllegalTransition, err)
+	}
+	if us.Status != StoryDraft {
+		t.Errorf("Expected Status to stay StoryDraft, got %s", us.Status)
+	}
+
+	// Setting the current status again is a no-op
+	if err := us.SetStatus(StoryDraft); err != nil {
+		t.Errorf("Expected no error setting the same status, got %v", err)
+	}
+}
+
+func TestUserStoryReadyGuard(t *testing.T) {
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+
+	// Ready requires acceptance criteria
+	err := us.SetStatus(StoryReady)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) || !errors.Is(err, ErrGuardFailed) {
+		t.Fatalf("Expected guard failure, got %v", err)
+	}
+	if transitionErr.From != string(StoryDraft) || transitionErr.To != string(StoryReady) {
+		t.Errorf("Expected transition Draft -> Ready, got %s -> %s", transitionErr.From, transitionErr.To)
+	}
+
+	// Ready requires an estimate as well
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	if err := us.SetStatus(StoryReady); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("Expected guard failure without an estimate, got %v", err)
+	}
+
+	us.SetEstimate(3)
+	if err := us.SetStatus(StoryReady); err != nil {
+		t.Errorf("Expected transition to succeed, got %v", err)
+	}
+}
+
+func TestDevTaskDoneGuard(t *testing.T) {
+	dep := NewDevTask("story-1", "Design login API", "dev-1")
+	dt := NewDevTask("story-1", "Implement login API", "dev-1")
+	dt.AddDependency(dep.ID)
+
+	if err := dt.SetStatus(TaskInProgress); err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+
+	// Done requires all dependencies Done
+	if err := dt.SetStatus(TaskDone, dep); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("Expected guard failure with an unfinished dependency, got %v", err)
+	}
+
+	// Dependencies that are not passed in count as unfinished
+	dep.SetStatus(TaskInProgress)
+	dep.SetStatus(TaskDone)
+	if err := dt.SetStatus(TaskDone); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("Expected guard failure with a missing dependency, got %v", err)
+	}
+
+	if err := dt.SetStatus(TaskDone, dep); err != nil {
+		t.Errorf("Expected transition to succeed, got %v", err)
+	}
+}
+
+func TestCheckEntry(t *testing.T) {
+	us := NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Status = StoryReady
+
+	// A story created Ready must meet the Ready guard
+	var transitionErr *TransitionError
+	if err := us.CheckEntry(); !errors.As(err, &transitionErr) || transitionErr.Reason != "ready requires acceptance criteria" {
+		t.Errorf("Expected the criteria guard to fail, got %v", err)
+	}
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	us.SetEstimate(3)
+	if err := us.CheckEntry(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	// A task created Done needs its dependencies done
+	dep := NewDevTask("story-1", "Design login API", "dev-1")
+	dt := NewDevTask("story-1", "Implement login API", "dev-1")
+	dt.AddDependency(dep.ID)
+	dt.Status = TaskDone
+	if err := dt.CheckEntry(dep); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("Expected error %v, got %v", ErrGuardFailed, err)
+	}
+	dep.Status = TaskDone
+	if err := dt.CheckEntry(dep); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	// A sprint created Active needs committed stories; statuses without a guard always pass
+	s := NewSprint("Ship login", time.Now(), time.Now().AddDate(0, 0, 14))
+	if err := s.CheckEntry(); err != nil {
+		t.Errorf("Expected no error for a planned sprint, got %v", err)
+	}
+	s.Status = SprintActive
+	if err := s.CheckEntry(); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("Expected error %v, got %v", ErrGuardFailed, err)
+	}
+}
+
+func TestSprintTransitions(t *testing.T) {
+	s := NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+
+	// Check that a new sprint is Planned
+	if s.Status != SprintPlanned {
+		t.Errorf("Expected Status to be SprintPlanned, got %s", s.Status)
+	}
+
+	// Active requires committed stories
+	if err := s.SetStatus(SprintActive); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("Expected guard failure without committed stories, got %v", err)
+	}
+
+	s.AddCommittedStory("story-1")
+	if err := s.SetStatus(SprintActive); err != nil {
+		t.Fatalf("Failed to start sprint: %v", err)
+	}
+	if err := s.SetStatus(SprintClosed); err != nil {
+		t.Fatalf("Failed to close sprint: %v", err)
+	}
+
+	// A closed sprint cannot be reopened
+	if err := s.SetStatus(SprintActive); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Expected error %v, got %v", ErrIllegalTransition, err)
+	}
+}
+
+func TestOnTransition(t *testing.T) {
+	var fired []Transition
+	remove := OnTransition(func(tr Transition) {
+		fired = append(fired, tr)
+	})
+
+	dt := NewDevTask("story-1", "Implement login feature", "dev-1")
+	dt.SetStatus(TaskInProgress)
+	dt.SetStatus(TaskDone)
+	dt.SetStatus(TaskBlocked) // Illegal from Done
+
+	// Check that only the successful transitions fired the hook
+	if len(fired) != 2 {
+		t.Fatalf("Expected 2 transitions, got %d", len(fired))
+	}
+	if fired[0].Kind != KindTask || fired[0].From != string(TaskTodo) || fired[0].To != string(TaskInProgress) {
+		t.Errorf("Expected first transition Todo -> InProgress, got %+v", fired[0])
+	}
+
+	// Removed hooks are no longer called
+	remove()
+	dt.SetStatus(TaskInProgress)
+	if len(fired) != 2 {
+		t.Errorf("Expected no more transitions after removing the hook, got %d", len(fired))
+	}
+}
@@ -2,6 +2,7 @@
 package models
 
 import (
+	"encoding/json"
 	"math/rand"
 	"strconv"
 	"time"
@@ -31,18 +32,40 @@ type UserStory struct {
 	ID                 string         `json:"id"`
 	Title              string         `json:"title"`
 	Description        string         `json:"description"`
-	AcceptanceCriteria []string       `json:"acceptance_criteria"`
-	BusinessValue      int            `json:"business_value"` // 1-10
+	AcceptanceCriteria []string       `json:"acceptance_criteria"` // Free text or Gherkin, see Scenarios
+	BusinessValue      int            `json:"business_value"`      // 1-10
 	Priority           PriorityLevel  `json:"priority"`
 	Status             StoryStatus    `json:"status"`
 	Estimate           *StoryEstimate `json:"estimate"`
+	EpicID             string         `json:"epic_id,omitempty"`          // Parent epic, if any
+	TimeCriticality    int            `json:"time_criticality,omitempty"` // 1-10, optional WSJF input
+	RiskReduction      int            `json:"risk_reduction,omitempty"`   // 1-10, optional WSJF input
+	MoSCoW             MoSCoW         `json:"moscow,omitempty"`
+	Rank               int            `json:"rank,omitempty"`      // Position in the ordered backlog, 1 is first
+	ParentID           string         `json:"parent_id,omitempty"` // Story this one was split from
+	Children           []string       `json:"children,omitempty"`  // Stories this one was split into
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
+
+	recorder Recorder // Receives mutation events once tracked
 }
 
+// MoSCoW represents the MoSCoW prioritization category of a user story
+type MoSCoW string
+
+const (
+	MoSCoWMust   MoSCoW = "Must"
+	MoSCoWShould MoSCoW = "Should"
+	MoSCoWCould  MoSCoW = "Could"
+	MoSCoWWont   MoSCoW = "Wont"
+)
+
 // StoryEstimate represents the estimation of a user story
 type StoryEstimate struct {
-	Points int `json:"points"` // Story points estimate
+	Points     int                 `json:"points"`               // Story points estimate
+	Confidence float64             `json:"confidence,omitempty"` // 0-1, from the last estimation session
+	Hours      *ThreePointEstimate `json:"hours,omitempty"`      // Team's three-point estimate
+	History    []EstimationSession `json:"history,omitempty"`    // Estimation sessions, oldest first
 }
 
 // NewUserStory creates a new UserStory with default values
@@ -72,22 +95,166 @@ func (us *UserStory) Update(title, description string, priority PriorityLevel, b
 	us.Priority = priority
 	us.BusinessValue = businessValue
 	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryUpdated, storyUpdatedData{
+		Title:         title,
+		Description:   description,
+		Priority:      priority,
+		BusinessValue: businessValue,
+	})
 }
 
-// SetStatus updates the status of the user story
-func (us *UserStory) SetStatus(status StoryStatus) {
+// SetStatus moves the user story to a new status, enforcing the story state machine
+func (us *UserStory) SetStatus(status StoryStatus) error {
+	from := us.Status
+	if status == from {
+		return nil
+	}
+	if err := checkTransition(storyTransitions, KindStory, us.ID, from, status, us.storyGuard(status)); err != nil {
+		return err
+	}
+
 	us.Status = status
 	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryStatusSet, valueData[StoryStatus]{Value: status})
+	fireTransition(Transition{Kind: KindStory, EntityID: us.ID, From: string(from), To: string(status), At: us.UpdatedAt})
+
+	return nil
 }
 
 // AddAcceptanceCriterion adds a new acceptance criterion
 func (us *UserStory) AddAcceptanceCriterion(criterion string) {
 	us.AcceptanceCriteria = append(us.AcceptanceCriteria, criterion)
 	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryCriterionAdded, valueData[string]{Value: criterion})
 }
 
 // SetEstimate sets the story point estimate
 func (us *UserStory) SetEstimate(points int) {
 	us.Estimate.Points = points
 	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryEstimateSet, valueData[int]{Value: points})
+}
+
+// SetEpic links the story to its parent epic; an empty ID detaches it
+func (us *UserStory) SetEpic(epicID string) {
+	us.EpicID = epicID
+	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryEpicSet, valueData[string]{Value: epicID})
+}
+
+// SetScoring sets the optional prioritization inputs of the story
+func (us *UserStory) SetScoring(timeCriticality, riskReduction int, moscow MoSCoW) {
+	us.TimeCriticality = timeCriticality
+	us.RiskReduction = riskReduction
+	us.MoSCoW = moscow
+	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryScoringSet, storyScoringData{
+		TimeCriticality: timeCriticality,
+		RiskReduction:   riskReduction,
+		MoSCoW:          moscow,
+	})
+}
+
+// SetRank sets the position of the story in the ordered backlog
+func (us *UserStory) SetRank(rank int) {
+	us.Rank = rank
+	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryRankSet, valueData[int]{Value: rank})
+}
+
+// SetParent records the story this one was split from
+func (us *UserStory) SetParent(parentID string) {
+	us.ParentID = parentID
+	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryParentSet, valueData[string]{Value: parentID})
+}
+
+// AddChild records a story split from this one, preventing duplicates
+func (us *UserStory) AddChild(childID string) {
+	if contains(us.Children, childID) {
+		return
+	}
+	us.Children = append(us.Children, childID)
+	us.UpdatedAt = time.Now()
+	emit(us.recorder, KindStory, us.ID, EventStoryChildAdded, valueData[string]{Value: childID})
+}
+
+// IsSplit reports whether the story was replaced by the stories split from it
+func (us *UserStory) IsSplit() bool {
+	return len(us.Children) > 0
+}
+
+// Track attaches a recorder and records the story's current state as its creation event
+func (us *UserStory) Track(r Recorder) {
+	us.recorder = r
+	emit(r, KindStory, us.ID, EventStoryCreated, us)
+}
+
+// Attach sends the story's further events to a recorder without recording its creation again,
+// as when loading it from the log or handing it to another actor
+func (us *UserStory) Attach(r Recorder) {
+	us.recorder = r
+}
+
+// Recorder returns the recorder the story's events go to, nil when it is not tracked
+func (us *UserStory) Recorder() Recorder {
+	return us.recorder
+}
+
+// Apply replays a recorded event onto the story without emitting new events
+func (us *UserStory) Apply(e Event) error {
+	var err error
+	switch e.Type {
+	case EventStoryCreated:
+		err = json.Unmarshal(e.Data, us)
+	case EventStoryUpdated:
+		var d storyUpdatedData
+		if err = json.Unmarshal(e.Data, &d); err == nil {
+			us.Title = d.Title
+			us.Description = d.Description
+			us.Priority = d.Priority
+			us.BusinessValue = d.BusinessValue
+		}
+	case EventStoryStatusSet:
+		us.Status, err = decodeValue[StoryStatus](e)
+	case EventStoryCriterionAdded:
+		var criterion string
+		if criterion, err = decodeValue[string](e); err == nil {
+			us.AcceptanceCriteria = append(us.AcceptanceCriteria, criterion)
+		}
+	case EventStoryEstimateSet:
+		if us.Estimate == nil {
+			us.Estimate = &StoryEstimate{}
+		}
+		us.Estimate.Points, err = decodeValue[int](e)
+	case EventStoryEstimated:
+		var session EstimationSession
+		if err = json.Unmarshal(e.Data, &session); err == nil {
+			applyEstimation(us, session)
+		}
+	case EventStoryEpicSet:
+		us.EpicID, err = decodeValue[string](e)
+	case EventStoryScoringSet:
+		var d storyScoringData
+		if err = json.Unmarshal(e.Data, &d); err == nil {
+			us.TimeCriticality = d.TimeCriticality
+			us.RiskReduction = d.RiskReduction
+			us.MoSCoW = d.MoSCoW
+		}
+	case EventStoryRankSet:
+		us.Rank, err = decodeValue[int](e)
+	case EventStoryParentSet:
+		us.ParentID, err = decodeValue[string](e)
+	case EventStoryChildAdded:
+		var childID string
+		if childID, err = decodeValue[string](e); err == nil {
+			us.Children = append(us.Children, childID)
+		}
+	default:
+		return ErrUnknownEvent
+	}
+	if err == nil && e.Type != EventStoryCreated {
+		us.UpdatedAt = e.Timestamp
+	}
+	return err
 }
@@ -99,9 +99,16 @@ func TestUserStoryUpdate(t *testing.T) {
 func TestUserStorySetStatus(t *testing.T) {
 	us := NewUserStory("Implement login feature", "Create a secure login system for users")
 
-	// Set status to InProgress
+	// Make the story ready, then set status to InProgress
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	us.SetEstimate(3)
+	if err := us.SetStatus(StoryReady); err != nil {
+		t.Fatalf("Failed to set status to StoryReady: %v", err)
+	}
 	newStatus := StoryInProgress
-	us.SetStatus(newStatus)
+	if err := us.SetStatus(newStatus); err != nil {
+		t.Fatalf("Failed to set status to %s: %v", newStatus, err)
+	}
 
 	// Check that the status was updated correctly
 	if us.Status != newStatus {
@@ -180,6 +187,41 @@ func TestUserStorySetEstimate(t *testing.T) {
 	}
 }
 
+func TestUserStorySplitLineage(t *testing.T) {
+	r := &recorderStub{}
+	parent := NewUserStory("Checkout", "Pay for the cart")
+	parent.Track(r)
+	child := NewUserStory("Checkout by card", "Pay for the cart by card")
+	child.SetParent(parent.ID)
+
+	// Check that an unsplit story is not reported as split
+	if parent.IsSplit() {
+		t.Errorf("Expected a new story not to be split")
+	}
+
+	parent.AddChild(child.ID)
+	parent.AddChild(child.ID)
+
+	// Check that the lineage was recorded without duplicates
+	if child.ParentID != parent.ID {
+		t.Errorf("Expected ParentID to be %s, got %s", parent.ID, child.ParentID)
+	}
+	if len(parent.Children) != 1 || !parent.IsSplit() {
+		t.Errorf("Expected one child and the parent to be split, got %v", parent.Children)
+	}
+
+	// Check that replaying the events restores the children
+	replayed := &UserStory{}
+	for _, e := range r.events {
+		if err := replayed.Apply(e); err != nil {
+			t.Fatalf("Failed to apply event %s: %v", e.Type, err)
+		}
+	}
+	if len(replayed.Children) != 1 || replayed.Children[0] != child.ID {
+		t.Errorf("Expected replayed children to be [%s], got %v", child.ID, replayed.Children)
+	}
+}
+
 func TestUserStorygenerateIDUS(t *testing.T) {
 	// Test that generateIDUS creates unique IDs
 	id1 := generateIDUS()
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// Change is one field of an entity changed by an event
+type Change struct {
+	Seq   int64            `json:"seq"`
+	At    time.Time        `json:"at"`
+	Actor string           `json:"actor,omitempty"`
+	Event models.EventType `json:"event"`
+	Field string           `json:"field"` // JSON name of the field, empty for the creation
+	Old   string           `json:"old"`
+	New   string           `json:"new"`
+}
+
+// History is the change log of one entity, oldest first
+type History []Change
+
+// Field returns the changes to one field
+func (h History) Field(name string) History {
+	var changes History
+	for _, c := range h {
+		if c.Field == name {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Reached returns when the field first took the value, as when a story first went InProgress
+func (h History) Reached(field, value string) (time.Time, bool) {
+	for _, c := range h {
+		if c.Field == field && c.New == value {
+			return c.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Last returns when the field last took the value, as when a reopened story was finally Done
+func (h History) Last(field, value string) (time.Time, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Field == field && h[i].New == value {
+			return h[i].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// EntityHistory replays the events of one entity, skipping undone ones, and returns every
+// field each event changed with its old and new value. UpdatedAt is left out as every
+// event touches it.
+func EntityHistory(events []models.Event, kind models.EntityKind, id string) History {
+	return histories(events, kind, func(entityID string) bool { return entityID == id })[id]
+}
+
+// Histories returns the change log of every entity of the kind in one pass over the events
+func Histories(events []models.Event, kind models.EntityKind) map[string]History {
+	return histories(events, kind, func(string) bool { return true })
+}
+
+// histories replays the events of the selected entities of the kind and diffs their fields
+func histories(events []models.Event, kind models.EntityKind, selected func(id string) bool) map[string]History {
+	undone := undoneSet(events)
+	snapshot := NewSnapshot()
+
+	result := make(map[string]History)
+	for _, e := range events {
+		if e.Kind != kind || e.Type == models.EventUndo || undone[e.Seq] || !selected(e.EntityID) {
+			continue
+		}
+
+		before := fields(snapshot, kind, e.EntityID)
+		snapshot.Apply(e)
+		after := fields(snapshot, kind, e.EntityID)
+		if after == nil {
+			continue // Event before the creation
+		}
+
+		if before == nil {
+			result[e.EntityID] = append(result[e.EntityID], Change{Seq: e.Seq, At: e.Timestamp, Actor: e.Actor, Event: e.Type})
+			continue
+		}
+		names := make([]string, 0, len(after))
+		for name := range after {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if name == "updated_at" || bytes.Equal(before[name], after[name]) {
+				continue
+			}
+			result[e.EntityID] = append(result[e.EntityID], Change{
+				Seq:   e.Seq,
+				At:    e.Timestamp,
+				Actor: e.Actor,
+				Event: e.Type,
+				Field: name,
+				Old:   render(before[name]),
+				New:   render(after[name]),
+			})
+		}
+	}
+	return result
+}
+
+// fields returns the JSON fields of the entity, or nil if it does not exist yet
+func fields(s *Snapshot, kind models.EntityKind, id string) map[string]json.RawMessage {
+	var entity any
+	switch kind {
+	case models.KindStory:
+		if us, ok := s.Stories[id]; ok {
+			entity = us
+		}
+	case models.KindTask:
+		if dt, ok := s.Tasks[id]; ok {
+			entity = dt
+		}
+	case models.KindSprint:
+		if sp, ok := s.Sprints[id]; ok {
+			entity = sp
+		}
+	}
+	if entity == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// render turns a JSON value into display text: strings unquoted, null and missing as empty
+func render(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+func TestEntityHistory(t *testing.T) {
+	s := New()
+	us := models.NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Track(models.WithActor(s, "po"))
+	us.Update("Implement login", us.Description, models.PriorityHigh, us.BusinessValue)
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+
+	dev := models.WithActor(s, "dev-1")
+	task := models.NewDevTask(us.ID, "Login form", "dev-1")
+	task.Track(dev)
+	task.SetStatus(models.TaskInProgress)
+
+	history := s.History(models.KindStory, us.ID)
+
+	// Check the creation and one change per field, attributed to the actor
+	if len(history) != 4 {
+		t.Fatalf("Expected creation and 3 field changes, got %+v", history)
+	}
+	if history[0].Field != "" || history[0].Event != models.EventStoryCreated {
+		t.Errorf("Expected the creation first, got %+v", history[0])
+	}
+	title := history.Field("title")
+	if len(title) != 1 || title[0].Old != "Implement login feature" || title[0].New != "Implement login" || title[0].Actor != "po" {
+		t.Errorf("Expected title change by po, got %+v", title)
+	}
+	if priority := history.Field("priority"); len(priority) != 1 || priority[0].Old != "Medium" || priority[0].New != "High" {
+		t.Errorf("Expected priority Medium -> High, got %+v", priority)
+	}
+	if criteria := history.Field("acceptance_criteria"); len(criteria) != 1 || criteria[0].Old != "[]" {
+		t.Errorf("Expected criteria change from [], got %+v", criteria)
+	}
+
+	// Check the task's status and start time were logged and can be queried
+	taskHistory := s.History(models.KindTask, task.ID)
+	started, ok := taskHistory.Reached("status", string(models.TaskInProgress))
+	if !ok || taskHistory.Field("status")[0].Actor != "dev-1" {
+		t.Errorf("Expected dev-1 to start the task, got %+v", taskHistory)
+	}
+	if len(taskHistory.Field("started_at")) != 1 || started.IsZero() {
+		t.Errorf("Expected started_at to be logged, got %+v", taskHistory)
+	}
+}
+
+func TestEntityHistorySkipsUndone(t *testing.T) {
+	s := New()
+	us := models.NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Track(s)
+	us.SetEstimate(3)
+	us.SetEstimate(5)
+	s.Undo()
+
+	// Check that only the surviving estimate is logged
+	estimate := s.History(models.KindStory, us.ID).Field("estimate")
+	if len(estimate) != 1 || estimate[0].New != `{"points":3}` {
+		t.Errorf("Expected one estimate change to 3 points, got %+v", estimate)
+	}
+	if _, ok := s.History(models.KindStory, us.ID).Last("status", string(models.StoryDone)); ok {
+		t.Errorf("Expected the story never to be Done")
+	}
+}
+
+func TestEntityHistoryTwoActors(t *testing.T) {
+	s := New()
+	us := models.NewUserStory("Implement login feature", "")
+	us.Track(models.WithActor(s, "po"))
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+
+	// The developers estimate the story the PO wrote
+	us.Attach(models.WithActor(s, "dev-1"))
+	us.SetEstimate(5)
+	us.Attach(models.WithActor(s, "po"))
+	us.Update("Implement login", us.Description, us.Priority, us.BusinessValue)
+
+	history := s.History(models.KindStory, us.ID)
+
+	// Check that attaching records no second creation
+	created := 0
+	for _, e := range s.Events() {
+		if e.Type == models.EventStoryCreated {
+			created++
+		}
+	}
+	if created != 1 || history[0].Actor != "po" {
+		t.Errorf("Expected one creation by po, got %d", created)
+	}
+
+	// Check that each change names the actor who made it
+	if criteria := history.Field("acceptance_criteria"); len(criteria) != 1 || criteria[0].Actor != "po" {
+		t.Errorf("Expected the criterion added by po, got %+v", criteria)
+	}
+	if estimate := history.Field("estimate"); len(estimate) != 1 || estimate[0].Actor != "dev-1" {
+		t.Errorf("Expected the estimate set by dev-1, got %+v", estimate)
+	}
+	if title := history.Field("title"); len(title) != 1 || title[0].Actor != "po" {
+		t.Errorf("Expected the title changed by po, got %+v", title)
+	}
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"egodteam/internal/data/models"
+)
+
+// Snapshot is the state of all models rebuilt from a sequence of events
+type Snapshot struct {
+	Stories map[string]*models.UserStory
+	Tasks   map[string]*models.DevTask
+	Sprints map[string]*models.Sprint
+	Seq     int64 // Sequence number of the last event applied
+}
+
+// NewSnapshot creates an empty snapshot
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		Stories: make(map[string]*models.UserStory),
+		Tasks:   make(map[string]*models.DevTask),
+		Sprints: make(map[string]*models.Sprint),
+	}
+}
+
+// Project replays events in order, skipping undone ones, and returns the resulting state
+func Project(events []models.Event) *Snapshot {
+	snapshot := NewSnapshot()
+
+	undone := undoneSet(events)
+	for _, e := range events {
+		if e.Seq > snapshot.Seq {
+			snapshot.Seq = e.Seq
+		}
+		if e.Type == models.EventUndo || undone[e.Seq] {
+			continue
+		}
+		snapshot.Apply(e)
+	}
+
+	return snapshot
+}
+
+// Apply dispatches an event to the model it belongs to; events for unknown entities are skipped
+func (s *Snapshot) Apply(e models.Event) {
+	switch e.Kind {
+	case models.KindStory:
+		story, ok := s.Stories[e.EntityID]
+		if !ok {
+			if e.Type != models.EventStoryCreated {
+				return
+			}
+			story = &models.UserStory{}
+			s.Stories[e.EntityID] = story
+		}
+		story.Apply(e)
+	case models.KindTask:
+		task, ok := s.Tasks[e.EntityID]
+		if !ok {
+			if e.Type != models.EventTaskCreated {
+				return
+			}
+			task = &models.DevTask{}
+			s.Tasks[e.EntityID] = task
+		}
+		task.Apply(e)
+	case models.KindSprint:
+		sprint, ok := s.Sprints[e.EntityID]
+		if !ok {
+			if e.Type != models.EventSprintCreated {
+				return
+			}
+			sprint = &models.Sprint{}
+			s.Sprints[e.EntityID] = sprint
+		}
+		sprint.Apply(e)
+	}
+}
+
+// undoneSet returns the sequence numbers reverted by undo events, honouring undo of undo
+func undoneSet(events []models.Event) map[int64]bool {
+	bySeq := make(map[int64]models.Event, len(events))
+	for _, e := range events {
+		bySeq[e.Seq] = e
+	}
+
+	undone := make(map[int64]bool)
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		if e.Type != models.EventUndo || undone[e.Seq] {
+			continue
+		}
+		if target, err := e.UndoTarget(); err == nil {
+			if _, ok := bySeq[target]; ok {
+				undone[target] = true
+			}
+		}
+	}
+
+	return undone
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+func TestProject(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	us := models.NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.Track(s)
+	us.SetEstimate(5)
+
+	dt := models.NewDevTask(us.ID, "Implement login API", "dev-1")
+	dt.Track(s)
+	dt.SetStatus(models.TaskInProgress)
+	dt.SetStatus(models.TaskDone)
+
+	snapshot := Project(s.Events())
+
+	// Check that every tracked entity was rebuilt
+	if len(snapshot.Stories) != 1 || len(snapshot.Tasks) != 1 || len(snapshot.Sprints) != 0 {
+		t.Fatalf("Expected 1 story, 1 task and 0 sprints, got %d, %d and %d",
+			len(snapshot.Stories), len(snapshot.Tasks), len(snapshot.Sprints))
+	}
+	if snapshot.Stories[us.ID].Estimate.Points != 5 {
+		t.Errorf("Expected Estimate Points to be 5, got %d", snapshot.Stories[us.ID].Estimate.Points)
+	}
+	if snapshot.Tasks[dt.ID].Status != models.TaskDone {
+		t.Errorf("Expected Status to be TaskDone, got %s", snapshot.Tasks[dt.ID].Status)
+	}
+	if snapshot.Seq != 5 {
+		t.Errorf("Expected Seq to be 5, got %d", snapshot.Seq)
+	}
+}
+
+func TestProjectSkipsOrphanEvents(t *testing.T) {
+	// A mutation without a preceding creation event is ignored
+	events := []models.Event{{Seq: 1, Kind: models.KindTask, Type: models.EventTaskStatusSet, EntityID: "task-1"}}
+
+	snapshot := Project(events)
+	if len(snapshot.Tasks) != 0 {
+		t.Errorf("Expected no tasks, got %d", len(snapshot.Tasks))
+	}
+}
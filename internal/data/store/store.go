@@ -0,0 +1,213 @@
+// Package store provides an append-only event store for model changes
+// in the agile team intelligent agent system.
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// EventStore defines the interface for the append-only event log
+// that records every model mutation and rebuilds state from it.
+type EventStore interface {
+	models.Recorder
+
+	// Events returns a copy of all recorded events in sequence order
+	Events() []models.Event
+
+	// Undo appends events reverting the latest change that is not yet undone,
+	// together with the events derived from it
+	Undo() error
+
+	// State projects all events into the current state of the models
+	State() *Snapshot
+
+	// StateAt projects the events recorded up to and including the given time
+	StateAt(t time.Time) *Snapshot
+
+	// History returns the field-level change log of one entity
+	History(kind models.EntityKind, id string) History
+
+	// Err returns the first error encountered while recording events
+	Err() error
+
+	// Close flushes and releases the underlying file
+	Close() error
+}
+
+// storeError implements the error interface
+type storeError string
+
+func (e storeError) Error() string {
+	return string(e)
+}
+
+// ErrStoreClosed is returned when trying to use a closed event store
+var ErrStoreClosed = storeError("event store is closed")
+
+// ErrNothingToUndo is returned when there is no event left to undo
+var ErrNothingToUndo = storeError("nothing to undo")
+
+// eventStore implements the EventStore interface with an optional JSON lines file
+type eventStore struct {
+	file   *os.File
+	events []models.Event
+	mutex  sync.RWMutex
+	err    error
+	closed bool
+}
+
+// New creates an in-memory event store
+func New() EventStore {
+	return &eventStore{}
+}
+
+// Open creates an event store persisted to a JSON lines file, loading any events already in it
+func Open(path string) (EventStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &eventStore{file: file}
+
+	// Load the existing log, one event per line
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e models.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			file.Close()
+			return nil, err
+		}
+		s.events = append(s.events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Record assigns the next sequence number and appends the event to the log
+func (s *eventStore) Record(event models.Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.append(event); err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// append writes the event; the caller must hold the write lock
+func (s *eventStore) append(event models.Event) error {
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	event.Seq = int64(len(s.events)) + 1
+	if s.file != nil {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := s.file.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+// Events returns a copy of all recorded events in sequence order
+func (s *eventStore) Events() []models.Event {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	events := make([]models.Event, len(s.events))
+	copy(events, s.events)
+	return events
+}
+
+// Undo appends events reverting the latest change that is not yet undone,
+// together with the events derived from it
+func (s *eventStore) Undo() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	undone := undoneSet(s.events)
+	var derived []int64
+	for i := len(s.events) - 1; i >= 0; i-- {
+		e := s.events[i]
+		if e.Type == models.EventUndo || undone[e.Seq] {
+			continue
+		}
+		if e.Derived() {
+			derived = append(derived, e.Seq)
+			continue
+		}
+		for _, seq := range append(derived, e.Seq) {
+			if err := s.append(models.NewUndoEvent(seq)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return ErrNothingToUndo
+}
+
+// State projects all events into the current state of the models
+func (s *eventStore) State() *Snapshot {
+	return Project(s.Events())
+}
+
+// StateAt projects the events recorded up to and including the given time
+func (s *eventStore) StateAt(t time.Time) *Snapshot {
+	events := s.Events()
+	n := 0
+	for n < len(events) && !events[n].Timestamp.After(t) {
+		n++
+	}
+	return Project(events[:n])
+}
+
+// History returns the field-level change log of one entity
+func (s *eventStore) History(kind models.EntityKind, id string) History {
+	return EntityHistory(s.Events(), kind, id)
+}
+
+// Err returns the first error encountered while recording events
+func (s *eventStore) Err() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.err
+}
+
+// Close flushes and releases the underlying file
+func (s *eventStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil // Already closed
+	}
+
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+func TestOpenPersistsAndReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open event store: %v", err)
+	}
+
+	us := models.NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	us.SetEstimate(3)
+	us.Track(s)
+	us.SetStatus(models.StoryReady)
+
+	if err := s.Err(); err != nil {
+		t.Fatalf("Unexpected record error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Failed to close event store: %v", err)
+	}
+
+	// Reopen and check that the log was reloaded
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen event store: %v", err)
+	}
+	defer reopened.Close()
+
+	events := reopened.Events()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events after reload, got %d", len(events))
+	}
+	if events[0].Seq != 1 || events[1].Seq != 2 {
+		t.Errorf("Expected sequence numbers 1 and 2, got %d and %d", events[0].Seq, events[1].Seq)
+	}
+
+	// New events continue the sequence
+	us.Track(reopened)
+	us.SetStatus(models.StoryInProgress)
+	events = reopened.Events()
+	if events[len(events)-1].Seq != 4 {
+		t.Errorf("Expected last sequence number to be 4, got %d", events[len(events)-1].Seq)
+	}
+}
+
+func TestOpenRejectsCorruptLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte("not json\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	if _, err := Open(path); err == nil {
+		t.Error("Expected error opening a corrupt log")
+	}
+}
+
+func TestUndo(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	us := models.NewUserStory("Implement login feature", "Create a secure login system for users")
+	us.AddAcceptanceCriterion("User can log in with valid credentials")
+	us.SetEstimate(3)
+	us.Track(s)
+	us.SetStatus(models.StoryReady)
+	us.SetStatus(models.StoryInProgress)
+
+	// Undo the last status change
+	if err := s.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if status := s.State().Stories[us.ID].Status; status != models.StoryReady {
+		t.Errorf("Expected Status to be StoryReady after one undo, got %s", status)
+	}
+
+	// Undo the next one, skipping the undo event itself
+	if err := s.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if status := s.State().Stories[us.ID].Status; status != models.StoryDraft {
+		t.Errorf("Expected Status to be StoryDraft after two undos, got %s", status)
+	}
+
+	// Undoing the creation removes the story entirely
+	if err := s.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if _, ok := s.State().Stories[us.ID]; ok {
+		t.Error("Expected story to be gone after undoing its creation")
+	}
+	if err := s.Undo(); err != ErrNothingToUndo {
+		t.Errorf("Expected error %v, got %v", ErrNothingToUndo, err)
+	}
+
+	// The log itself is append-only
+	if len(s.Events()) != 6 {
+		t.Errorf("Expected 6 events in the log, got %d", len(s.Events()))
+	}
+}
+
+func TestUndoWithBurnDown(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	us := models.NewUserStory("Implement login feature", "")
+	us.SetEstimate(3)
+	us.Track(s)
+	sprint := models.NewSprint("Login", time.Now(), time.Now().AddDate(0, 0, 10))
+	sprint.Track(s)
+	sprint.TrackPoints(models.StoryPoints(us))
+	sprint.AddCommittedStory(us.ID)
+	sprint.AddCompletedStory(us.ID)
+
+	// Check that one undo reverts the completion and the snapshot derived from it
+	if err := s.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	state := s.State().Sprints[sprint.ID]
+	if len(state.Completed) != 0 {
+		t.Errorf("Expected the story to be no longer completed, got %v", state.Completed)
+	}
+	if len(state.BurnDown) != 1 || state.BurnDown[0].Left != 3 {
+		t.Errorf("Expected the snapshot with 3 points left, got %+v", state.BurnDown)
+	}
+
+	// Check that the next undo reverts the commitment
+	if err := s.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if state := s.State().Sprints[sprint.ID]; len(state.Committed) != 0 || len(state.BurnDown) != 0 {
+		t.Errorf("Expected nothing committed, got %v and %+v", state.Committed, state.BurnDown)
+	}
+}
+
+func TestStateAt(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	sprint := models.NewSprint("Implement user authentication", time.Now(), time.Now().Add(7*24*time.Hour))
+	sprint.Track(s)
+	sprint.AddCommittedStory("story-1")
+
+	time.Sleep(10 * time.Millisecond)
+	sprintStart := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	sprint.AddCommittedStory("story-2")
+	sprint.AddCompletedStory("story-1")
+
+	// The past state only includes events up to sprint start
+	past := s.StateAt(sprintStart).Sprints[sprint.ID]
+	if len(past.Committed) != 1 || len(past.Completed) != 0 {
+		t.Errorf("Expected 1 committed and 0 completed at sprint start, got %v and %v", past.Committed, past.Completed)
+	}
+
+	current := s.State().Sprints[sprint.ID]
+	if len(current.Committed) != 2 || len(current.Completed) != 1 {
+		t.Errorf("Expected 2 committed and 1 completed now, got %v and %v", current.Committed, current.Completed)
+	}
+}
+
+func TestClose(t *testing.T) {
+	s := New()
+	if err := s.Close(); err != nil {
+		t.Fatalf("Failed to close event store: %v", err)
+	}
+
+	// Recording after close should surface ErrStoreClosed through Err
+	s.Record(models.Event{Type: models.EventStoryCreated})
+	if err := s.Err(); err != ErrStoreClosed {
+		t.Errorf("Expected error %v, got %v", ErrStoreClosed, err)
+	}
+	if err := s.Undo(); err != ErrStoreClosed {
+		t.Errorf("Expected error %v, got %v", ErrStoreClosed, err)
+	}
+}
@@ -0,0 +1,338 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// DateLayout is the short date format accepted on import besides RFC 3339
+const DateLayout = "2006-01-02"
+
+// listSeparator separates IDs within a CSV cell
+const listSeparator = ";"
+
+// Mapping renames columns: each field name maps to the CSV header used for it.
+// Fields not mapped use their own name as header; headers match case-insensitively.
+type Mapping map[string]string
+
+// header returns the column header of the field
+func (m Mapping) header(field string) string {
+	if h, ok := m[field]; ok {
+		return h
+	}
+	return field
+}
+
+// column reads and writes one field of an entity as CSV text. Reference columns hold
+// source IDs on import, which the import maps to real IDs.
+type column[T any] struct {
+	field    string
+	required bool
+	get      func(T) string
+	set      func(T, string) error
+}
+
+// storyColumns are the CSV fields of a story; criteria are one per line
+var storyColumns = []column[*models.UserStory]{
+	{"id", false, func(us *models.UserStory) string { return us.ID }, nil},
+	{"title", true, func(us *models.UserStory) string { return us.Title }, func(us *models.UserStory, v string) error { us.Title = v; return nil }},
+	{"description", false, func(us *models.UserStory) string { return us.Description }, func(us *models.UserStory, v string) error { us.Description = v; return nil }},
+	{"criteria", false, func(us *models.UserStory) string { return strings.Join(us.AcceptanceCriteria, "\n") }, func(us *models.UserStory, v string) error {
+		us.AcceptanceCriteria = lines(v)
+		return nil
+	}},
+	{"points", false, func(us *models.UserStory) string { return strconv.Itoa(storyPoints(us)) }, func(us *models.UserStory, v string) error {
+		n, err := parseInt(v, 0, math.MaxInt32)
+		us.Estimate = &models.StoryEstimate{Points: n}
+		return err
+	}},
+	{"value", false, func(us *models.UserStory) string { return strconv.Itoa(us.BusinessValue) }, func(us *models.UserStory, v string) (err error) {
+		us.BusinessValue, err = parseInt(v, 1, 10)
+		return err
+	}},
+	{"priority", false, func(us *models.UserStory) string { return string(us.Priority) }, func(us *models.UserStory, v string) (err error) {
+		us.Priority, err = parseEnum(v, models.PriorityHigh, models.PriorityMedium, models.PriorityLow)
+		return err
+	}},
+	{"status", false, func(us *models.UserStory) string { return string(us.Status) }, func(us *models.UserStory, v string) (err error) {
+		us.Status, err = parseEnum(v, models.StoryDraft, models.StoryReady, models.StoryInProgress, models.StoryDone)
+		return err
+	}},
+	{"moscow", false, func(us *models.UserStory) string { return string(us.MoSCoW) }, func(us *models.UserStory, v string) (err error) {
+		us.MoSCoW, err = parseEnum(v, models.MoSCoWMust, models.MoSCoWShould, models.MoSCoWCould, models.MoSCoWWont)
+		return err
+	}},
+	{"rank", false, func(us *models.UserStory) string { return strconv.Itoa(us.Rank) }, func(us *models.UserStory, v string) (err error) {
+		us.Rank, err = parseInt(v, 0, math.MaxInt32)
+		return err
+	}},
+	{"epic", false, func(us *models.UserStory) string { return us.EpicID }, func(us *models.UserStory, v string) error { us.EpicID = v; return nil }},
+	{"parent", false, func(us *models.UserStory) string { return us.ParentID }, func(us *models.UserStory, v string) error { us.ParentID = v; return nil }},
+}
+
+// taskColumns are the CSV fields of a task; the estimate is in hours
+var taskColumns = []column[*models.DevTask]{
+	{"id", false, func(dt *models.DevTask) string { return dt.ID }, nil},
+	{"story_id", true, func(dt *models.DevTask) string { return dt.StoryID }, func(dt *models.DevTask, v string) error { dt.StoryID = v; return nil }},
+	{"title", true, func(dt *models.DevTask) string { return dt.Title }, func(dt *models.DevTask, v string) error { dt.Title = v; return nil }},
+	{"type", false, func(dt *models.DevTask) string { return string(dt.Type) }, func(dt *models.DevTask, v string) (err error) {
+		dt.Type, err = parseEnum(v, models.TaskDevelopment, models.TaskTesting, models.TaskDeployment)
+		return err
+	}},
+	{"estimate_hours", false, func(dt *models.DevTask) string { return strconv.FormatFloat(dt.Estimate.Hours(), 'f', -1, 64) }, func(dt *models.DevTask, v string) error {
+		hours, err := strconv.ParseFloat(v, 64)
+		if err != nil || hours < 0 {
+			return fmt.Errorf("%q is not a number of hours", v)
+		}
+		dt.Estimate = time.Duration(hours * float64(time.Hour))
+		return nil
+	}},
+	{"status", false, func(dt *models.DevTask) string { return string(dt.Status) }, func(dt *models.DevTask, v string) (err error) {
+		dt.Status, err = parseEnum(v, models.TaskTodo, models.TaskInProgress, models.TaskBlocked, models.TaskDone)
+		return err
+	}},
+	{"assignee", false, func(dt *models.DevTask) string { return dt.Assignee }, func(dt *models.DevTask, v string) error { dt.Assignee = v; return nil }},
+	{"dependencies", false, func(dt *models.DevTask) string { return strings.Join(dt.Dependencies, listSeparator) }, func(dt *models.DevTask, v string) error {
+		dt.Dependencies = list(v)
+		return nil
+	}},
+}
+
+// sprintColumns are the CSV fields of a sprint; committed and completed list story IDs
+var sprintColumns = []column[*models.Sprint]{
+	{"id", false, func(s *models.Sprint) string { return s.ID }, nil},
+	{"goal", false, func(s *models.Sprint) string { return s.Goal }, func(s *models.Sprint, v string) error { s.Goal = v; return nil }},
+	{"status", false, func(s *models.Sprint) string { return string(s.Status) }, func(s *models.Sprint, v string) (err error) {
+		s.Status, err = parseEnum(v, models.SprintPlanned, models.SprintActive, models.SprintClosed)
+		return err
+	}},
+	{"start", true, func(s *models.Sprint) string { return s.StartDate.Format(time.RFC3339) }, func(s *models.Sprint, v string) (err error) {
+		s.StartDate, err = parseDate(v)
+		return err
+	}},
+	{"end", true, func(s *models.Sprint) string { return s.EndDate.Format(time.RFC3339) }, func(s *models.Sprint, v string) (err error) {
+		s.EndDate, err = parseDate(v)
+		return err
+	}},
+	{"velocity", false, func(s *models.Sprint) string { return strconv.Itoa(s.Velocity) }, func(s *models.Sprint, v string) (err error) {
+		s.Velocity, err = parseInt(v, 0, math.MaxInt32)
+		return err
+	}},
+	{"committed", false, func(s *models.Sprint) string { return strings.Join(s.Committed, listSeparator) }, func(s *models.Sprint, v string) error {
+		s.Committed = list(v)
+		return nil
+	}},
+	{"completed", false, func(s *models.Sprint) string { return strings.Join(s.Completed, listSeparator) }, func(s *models.Sprint, v string) error {
+		s.Completed = list(v)
+		return nil
+	}},
+}
+
+// ImportCSV reads stories, tasks or sprints from CSV with a header row and imports them.
+// Tasks must reference stories in opts.Existing, and sprints stories there as well.
+func ImportCSV(r io.Reader, kind models.EntityKind, mapping Mapping, opts Options) (*Result, error) {
+	src := &source{}
+	var err error
+	switch kind {
+	case models.KindStory:
+		src.stories, err = readCSV(r, src, storyColumns, mapping, func() *models.UserStory { return models.NewUserStory("", "") })
+	case models.KindTask:
+		src.tasks, err = readCSV(r, src, taskColumns, mapping, func() *models.DevTask { return models.NewDevTask("", "", "") })
+	case models.KindSprint:
+		src.sprints, err = readCSV(r, src, sprintColumns, mapping, func() *models.Sprint { return models.NewSprint("", time.Time{}, time.Time{}) })
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKind, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return apply(src, opts)
+}
+
+// ExportCSV writes the backlog's stories, tasks or sprints as CSV with a header row
+func ExportCSV(w io.Writer, b *Backlog, kind models.EntityKind, mapping Mapping) error {
+	switch kind {
+	case models.KindStory:
+		return writeCSV(w, b.Stories, storyColumns, mapping)
+	case models.KindTask:
+		return writeCSV(w, b.Tasks, taskColumns, mapping)
+	case models.KindSprint:
+		return writeCSV(w, b.Sprints, sprintColumns, mapping)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedKind, kind)
+}
+
+// checkMapping rejects mappings of fields the columns do not have
+func checkMapping[T any](columns []column[T], mapping Mapping) error {
+	for field := range mapping {
+		found := false
+		for _, c := range columns {
+			found = found || c.field == field
+		}
+		if !found {
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+	}
+	return nil
+}
+
+// readCSV reads one entity per record, collecting invalid cells as problems. Empty cells
+// keep the defaults of newValue.
+func readCSV[T any](r io.Reader, src *source, columns []column[T], mapping Mapping, newValue func() T) ([]entry[T], error) {
+	if err := checkMapping(columns, mapping); err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: no header row", ErrMissingColumn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	positions := make([]int, len(columns))
+	for i, c := range columns {
+		pos, ok := index[strings.ToLower(mapping.header(c.field))]
+		if !ok {
+			if c.required {
+				return nil, fmt.Errorf("%w: %s", ErrMissingColumn, mapping.header(c.field))
+			}
+			pos = -1
+		}
+		positions[i] = pos
+	}
+
+	var entries []entry[T]
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		e := entry[T]{line: line, value: newValue()}
+		for i, c := range columns {
+			if positions[i] < 0 || positions[i] >= len(record) {
+				continue
+			}
+			v := strings.TrimSpace(record[positions[i]])
+			switch {
+			case v == "":
+			case c.set == nil:
+				e.id = v
+			default:
+				if err := c.set(e.value, v); err != nil {
+					src.problem(line, c.field, "%v", err)
+				}
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// writeCSV writes a header row and one record per value
+func writeCSV[T any](w io.Writer, values []T, columns []column[T], mapping Mapping) error {
+	if err := checkMapping(columns, mapping); err != nil {
+		return err
+	}
+	out := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = mapping.header(c.field)
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	for _, v := range values {
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			record[i] = c.get(v)
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// storyPoints returns the story's points, 0 when unestimated
+func storyPoints(us *models.UserStory) int {
+	if us.Estimate == nil {
+		return 0
+	}
+	return us.Estimate.Points
+}
+
+// parseInt parses an integer within [min, max]
+func parseInt(v string, min, max int) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", v)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("%d is outside %d-%d", n, min, max)
+	}
+	return n, nil
+}
+
+// parseEnum matches the value case-insensitively against the allowed values
+func parseEnum[T ~string](v string, allowed ...T) (T, error) {
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		if strings.EqualFold(v, string(a)) {
+			return a, nil
+		}
+		names[i] = string(a)
+	}
+	return "", fmt.Errorf("%q is not one of %s", v, strings.Join(names, ", "))
+}
+
+// parseDate parses an RFC 3339 time or a date
+func parseDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(DateLayout, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date", v)
+	}
+	return t, nil
+}
+
+// lines splits a cell into its non-empty trimmed lines
+func lines(v string) []string {
+	out := []string{}
+	for _, l := range strings.Split(v, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// list splits a cell into its non-empty trimmed IDs
+func list(v string) []string {
+	out := []string{}
+	for _, id := range strings.Split(v, listSeparator) {
+		if id = strings.TrimSpace(id); id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package exchange
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+func TestCSVMapping(t *testing.T) {
+	input := `Issue key,Summary,Story Points,Acceptance
+PROJ-1,Checkout,8,"Pays by card
+Shows a receipt"
+`
+	mapping := Mapping{"id": "Issue key", "title": "summary", "points": "Story Points", "criteria": "Acceptance"}
+	result, err := ImportCSV(strings.NewReader(input), models.KindStory, mapping, Options{})
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	// Check that mapped headers match case-insensitively and criteria split by line
+	us := result.Stories[0]
+	if us.Title != "Checkout" || us.Estimate.Points != 8 || len(us.AcceptanceCriteria) != 2 || result.IDs["PROJ-1"] != us.ID {
+		t.Errorf("Expected the mapped story, got %+v", us)
+	}
+
+	// Check that unknown fields and missing required columns are rejected
+	if _, err := ImportCSV(strings.NewReader(input), models.KindStory, Mapping{"summary": "Summary"}, Options{}); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+	if _, err := ImportCSV(strings.NewReader(input), models.KindStory, nil, Options{}); !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("Expected ErrMissingColumn, got %v", err)
+	}
+	if _, err := ImportCSV(strings.NewReader(""), models.KindStory, nil, Options{}); !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("Expected ErrMissingColumn for empty input, got %v", err)
+	}
+	if _, err := ImportCSV(strings.NewReader(input), models.KindImpediment, nil, Options{}); !errors.Is(err, ErrUnsupportedKind) {
+		t.Errorf("Expected ErrUnsupportedKind, got %v", err)
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	us := models.NewUserStory("Checkout", "Pay for the cart")
+	us.AddAcceptanceCriterion("Pays by card")
+	us.AddAcceptanceCriterion("Shows a receipt")
+	us.SetEstimate(5)
+	dt := models.NewDevTask(us.ID, "Build the form", "dev-1")
+	dt.Update(dt.Title, models.TaskTesting, 90*time.Minute, dt.Assignee)
+	sprint := models.NewSprint("Ship checkout", time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC), time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC))
+	sprint.AddCommittedStory(us.ID)
+	b := &Backlog{Stories: []*models.UserStory{us}, Tasks: []*models.DevTask{dt}, Sprints: []*models.Sprint{sprint}}
+
+	// Check that each kind comes back as exported, under new IDs
+	opts := Options{IDs: map[string]string{}}
+	for _, kind := range []models.EntityKind{models.KindStory, models.KindTask, models.KindSprint} {
+		var buf bytes.Buffer
+		if err := ExportCSV(&buf, b, kind, nil); err != nil {
+			t.Fatalf("Failed to export %s: %v", kind, err)
+		}
+		result, err := ImportCSV(&buf, kind, nil, opts)
+		if err != nil {
+			t.Fatalf("Failed to import %s: %v", kind, err)
+		}
+		if opts.Existing == nil {
+			opts.Existing = &Backlog{}
+		}
+		opts.Existing.Stories = append(opts.Existing.Stories, result.Stories...)
+		opts.Existing.Tasks = append(opts.Existing.Tasks, result.Tasks...)
+		for source, id := range result.IDs {
+			opts.IDs[source] = id
+		}
+	}
+
+	story, task := opts.Existing.Stories[0], opts.Existing.Tasks[0]
+	if story.ID == us.ID || story.Description != us.Description || len(story.AcceptanceCriteria) != 2 || story.Estimate.Points != 5 {
+		t.Errorf("Expected a copy of the story under a new ID, got %+v", story)
+	}
+	if task.StoryID != story.ID || task.Type != models.TaskTesting || task.Estimate != 90*time.Minute || task.Assignee != "dev-1" {
+		t.Errorf("Expected a copy of the task on the new story, got %+v", task)
+	}
+	if id := opts.IDs[sprint.ID]; id == "" || id == sprint.ID {
+		t.Errorf("Expected the sprint under a new ID, got %q", id)
+	}
+}
+
+func TestCSVSprints(t *testing.T) {
+	existing := &Backlog{Stories: []*models.UserStory{{ID: "story-1", Title: "Checkout"}}}
+	input := `goal,start,end,committed
+Ship checkout,2025-09-22,2025-10-03,story-1
+Backwards,2025-10-03,2025-09-22,
+`
+	result, err := ImportCSV(strings.NewReader(input), models.KindSprint, nil, Options{Existing: existing, DryRun: true})
+
+	// Check that dates parse and a sprint ending before it starts is a problem
+	if !errors.Is(err, ErrInvalidImport) || len(result.Problems) != 1 || result.Problems[0].Line != 3 {
+		t.Fatalf("Expected one problem on line 3, got %v", result.Problems)
+	}
+	s := result.Sprints[0]
+	if !s.StartDate.Equal(time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)) || len(s.Committed) != 1 || s.Committed[0] != "story-1" {
+		t.Errorf("Expected the sprint starting 2025-09-22 with story-1, got %+v", s)
+	}
+}
@@ -0,0 +1,137 @@
+// Package exchange imports and exports backlogs from and to other tools: CSV with column
+// mapping, Markdown checklists and Jira issue JSON. Imports are validated, given fresh IDs
+// and checked for duplicates before anything is recorded.
+package exchange
+
+import (
+	"fmt"
+	"sort"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+type exchangeError string
+
+func (e exchangeError) Error() string {
+	return string(e)
+}
+
+// ErrInvalidImport is returned when an import has problems; nothing is recorded
+var ErrInvalidImport = exchangeError("invalid import")
+
+// ErrMissingColumn is returned when a CSV file lacks a required column
+var ErrMissingColumn = exchangeError("missing column")
+
+// ErrUnknownField is returned when a column mapping names a field the entity does not have
+var ErrUnknownField = exchangeError("unknown field")
+
+// ErrUnsupportedKind is returned when importing or exporting an entity kind without a format
+var ErrUnsupportedKind = exchangeError("unsupported entity kind")
+
+// Backlog is a set of stories, tasks and sprints moving in or out of the system
+type Backlog struct {
+	Stories []*models.UserStory `json:"stories"`
+	Tasks   []*models.DevTask   `json:"tasks"`
+	Sprints []*models.Sprint    `json:"sprints"`
+}
+
+// FromSnapshot collects the snapshot's entities, stories by rank then creation, tasks and
+// sprints by creation and start
+func FromSnapshot(s *store.Snapshot) *Backlog {
+	b := &Backlog{}
+	for _, us := range s.Stories {
+		b.Stories = append(b.Stories, us)
+	}
+	for _, dt := range s.Tasks {
+		b.Tasks = append(b.Tasks, dt)
+	}
+	for _, sp := range s.Sprints {
+		b.Sprints = append(b.Sprints, sp)
+	}
+	sort.Slice(b.Stories, func(i, j int) bool {
+		a, c := b.Stories[i], b.Stories[j]
+		if (a.Rank == 0) != (c.Rank == 0) {
+			return a.Rank != 0
+		}
+		if a.Rank != c.Rank {
+			return a.Rank < c.Rank
+		}
+		if !a.CreatedAt.Equal(c.CreatedAt) {
+			return a.CreatedAt.Before(c.CreatedAt)
+		}
+		return a.ID < c.ID
+	})
+	sort.Slice(b.Tasks, func(i, j int) bool {
+		if !b.Tasks[i].CreatedAt.Equal(b.Tasks[j].CreatedAt) {
+			return b.Tasks[i].CreatedAt.Before(b.Tasks[j].CreatedAt)
+		}
+		return b.Tasks[i].ID < b.Tasks[j].ID
+	})
+	sort.Slice(b.Sprints, func(i, j int) bool {
+		if !b.Sprints[i].StartDate.Equal(b.Sprints[j].StartDate) {
+			return b.Sprints[i].StartDate.Before(b.Sprints[j].StartDate)
+		}
+		return b.Sprints[i].ID < b.Sprints[j].ID
+	})
+	return b
+}
+
+// tasksOf returns the tasks of the story in backlog order
+func (b *Backlog) tasksOf(storyID string) []*models.DevTask {
+	var tasks []*models.DevTask
+	for _, dt := range b.Tasks {
+		if dt.StoryID == storyID {
+			tasks = append(tasks, dt)
+		}
+	}
+	return tasks
+}
+
+// Problem is an entry of an import that cannot be taken as is
+type Problem struct {
+	Line    int    `json:"line"` // Line or record number in the source, 1 is the first
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Field == "" {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", p.Line, p.Field, p.Message)
+}
+
+// Duplicate is an imported entry skipped because it matches an existing or earlier one
+type Duplicate struct {
+	Kind       models.EntityKind `json:"kind"`
+	Line       int               `json:"line"`
+	SourceID   string            `json:"source_id,omitempty"`
+	Title      string            `json:"title"`
+	ExistingID string            `json:"existing_id"` // References to the entry now point here
+}
+
+// Options control how an import is applied
+type Options struct {
+	DryRun   bool            // Validate and map IDs without recording anything
+	Existing *Backlog        // Entities already in the system, for duplicates and references
+	Recorder models.Recorder // Receives the creation events of the imported entities
+
+	// IDs maps source IDs of earlier imports, so that references across files resolve
+	IDs map[string]string
+}
+
+// Result is the outcome of an import
+type Result struct {
+	Backlog
+	IDs        map[string]string `json:"ids"` // Source IDs to the new or existing IDs
+	Problems   []Problem         `json:"problems"`
+	Duplicates []Duplicate       `json:"duplicates"`
+	Recorded   bool              `json:"recorded"`
+}
+
+// Summary describes the result in one line
+func (r *Result) Summary() string {
+	return fmt.Sprintf("%d stories, %d tasks, %d sprints, %d duplicates, %d problems",
+		len(r.Stories), len(r.Tasks), len(r.Sprints), len(r.Duplicates), len(r.Problems))
+}
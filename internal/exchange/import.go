@@ -0,0 +1,310 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"egodteam/internal/data/models"
+)
+
+// entry is an entity read from a source with where it came from. References between
+// entries of the same source use their keys until the import maps them to real IDs.
+type entry[T any] struct {
+	line  int
+	id    string // Source ID, empty when the source has none
+	value T
+}
+
+// key identifies the entry within its source
+func (e entry[T]) key() string {
+	if e.id != "" {
+		return e.id
+	}
+	return "#" + strconv.Itoa(e.line)
+}
+
+// source is everything read from one import source
+type source struct {
+	stories  []entry[*models.UserStory]
+	tasks    []entry[*models.DevTask]
+	sprints  []entry[*models.Sprint]
+	problems []Problem
+}
+
+// problem records a problem with an entry
+func (s *source) problem(line int, field, format string, args ...any) {
+	s.problems = append(s.problems, Problem{Line: line, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// normalize folds case and spacing so titles can be compared
+func normalize(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// apply validates the source, skips duplicates, maps source IDs to new ones and records
+// the entities unless the import is a dry run or has problems
+func apply(src *source, opts Options) (*Result, error) {
+	existing := opts.Existing
+	if existing == nil {
+		existing = &Backlog{}
+	}
+	r := &Result{IDs: make(map[string]string), Duplicates: []Duplicate{}}
+	duplicate := func(kind models.EntityKind, line int, id, title, existingID string) {
+		r.Duplicates = append(r.Duplicates, Duplicate{Kind: kind, Line: line, SourceID: id, Title: title, ExistingID: existingID})
+		if id != "" {
+			r.IDs[id] = existingID
+		}
+	}
+
+	// Stories, matched by ID or title against existing and earlier ones
+	storyIDs := make(map[string]string)
+	knownStories := make(map[string]bool)
+	storyTitles := make(map[string]string)
+	for _, us := range existing.Stories {
+		knownStories[us.ID] = true
+		storyTitles[normalize(us.Title)] = us.ID
+	}
+	for ref, id := range opts.IDs {
+		if knownStories[id] {
+			storyIDs[ref] = id
+		}
+	}
+	for _, e := range src.stories {
+		us := e.value
+		title := normalize(us.Title)
+		switch {
+		case title == "":
+			src.problem(e.line, "title", "is required")
+			continue
+		case knownStories[e.id]:
+			duplicate(models.KindStory, e.line, e.id, us.Title, e.id)
+			storyIDs[e.key()] = e.id
+			continue
+		case storyIDs[e.key()] != "":
+			duplicate(models.KindStory, e.line, e.id, us.Title, storyIDs[e.key()])
+			continue
+		case storyTitles[title] != "":
+			duplicate(models.KindStory, e.line, e.id, us.Title, storyTitles[title])
+			storyIDs[e.key()] = storyTitles[title]
+			continue
+		}
+		storyIDs[e.key()] = us.ID
+		storyTitles[title] = us.ID
+		if e.id != "" {
+			r.IDs[e.id] = us.ID
+		}
+		r.Stories = append(r.Stories, us)
+	}
+	story := func(ref string) (string, bool) {
+		if id, ok := storyIDs[ref]; ok {
+			return id, true
+		}
+		return ref, knownStories[ref]
+	}
+	for _, e := range src.stories {
+		us := e.value
+		if storyIDs[e.key()] != us.ID {
+			continue
+		}
+		if us.ParentID != "" {
+			parent, ok := story(us.ParentID)
+			if !ok {
+				src.problem(e.line, "parent", "unknown story %q", us.ParentID)
+			}
+			us.ParentID = parent
+		}
+		for i, child := range us.Children {
+			id, ok := story(child)
+			if !ok {
+				src.problem(e.line, "children", "unknown story %q", child)
+			}
+			us.Children[i] = id
+		}
+		checkEntry(src, e.line, us.CheckEntry())
+	}
+
+	// Tasks, matched by ID or by title within their story
+	taskIDs := make(map[string]string)
+	knownTasks := make(map[string]bool)
+	taskTitles := make(map[string]string)
+	for _, dt := range existing.Tasks {
+		knownTasks[dt.ID] = true
+		taskTitles[dt.StoryID+"\x00"+normalize(dt.Title)] = dt.ID
+	}
+	for ref, id := range opts.IDs {
+		if knownTasks[id] {
+			taskIDs[ref] = id
+		}
+	}
+	for _, e := range src.tasks {
+		dt := e.value
+		if normalize(dt.Title) == "" {
+			src.problem(e.line, "title", "is required")
+			continue
+		}
+		storyID, ok := story(dt.StoryID)
+		if !ok {
+			src.problem(e.line, "story_id", "unknown story %q", dt.StoryID)
+			continue
+		}
+		dt.StoryID = storyID
+		title := storyID + "\x00" + normalize(dt.Title)
+		switch {
+		case knownTasks[e.id]:
+			duplicate(models.KindTask, e.line, e.id, dt.Title, e.id)
+			taskIDs[e.key()] = e.id
+			continue
+		case taskIDs[e.key()] != "":
+			duplicate(models.KindTask, e.line, e.id, dt.Title, taskIDs[e.key()])
+			continue
+		case taskTitles[title] != "":
+			duplicate(models.KindTask, e.line, e.id, dt.Title, taskTitles[title])
+			taskIDs[e.key()] = taskTitles[title]
+			continue
+		}
+		taskIDs[e.key()] = dt.ID
+		taskTitles[title] = dt.ID
+		if e.id != "" {
+			r.IDs[e.id] = dt.ID
+		}
+		r.Tasks = append(r.Tasks, dt)
+	}
+	refs := make(map[string][]string)
+	unresolved := make(map[string]bool)
+	for _, e := range src.tasks {
+		dt := e.value
+		if taskIDs[e.key()] != dt.ID {
+			continue
+		}
+		refs[dt.ID] = append([]string(nil), dt.Dependencies...)
+		for i, dep := range dt.Dependencies {
+			if id, ok := taskIDs[dep]; ok {
+				dt.Dependencies[i] = id
+			} else if !knownTasks[dep] {
+				src.problem(e.line, "dependencies", "unknown task %q", dep)
+				unresolved[dt.ID] = true
+			}
+		}
+	}
+
+	// Dependencies must not form a cycle with each other or the existing tasks; adding them
+	// in line order to a graph of copies reports the one closing the cycle
+	var nodes []*models.DevTask
+	for _, dt := range existing.Tasks {
+		nodes = append(nodes, &models.DevTask{ID: dt.ID, Dependencies: known(dt.Dependencies, knownTasks)})
+	}
+	for _, dt := range r.Tasks {
+		nodes = append(nodes, &models.DevTask{ID: dt.ID})
+	}
+	if graph, err := models.NewTaskGraph(nodes...); err == nil {
+		for _, e := range src.tasks {
+			dt := e.value
+			if taskIDs[e.key()] != dt.ID {
+				continue
+			}
+			for i, dep := range dt.Dependencies {
+				if err := graph.AddDependency(dt.ID, dep); errors.Is(err, models.ErrDependencyCycle) {
+					src.problem(e.line, "dependencies", "%q closes a dependency cycle", refs[dt.ID][i])
+				}
+			}
+		}
+	}
+
+	// Task statuses are checked against the existing and imported dependencies; tasks with
+	// unknown dependencies are already reported
+	tasks := append(append([]*models.DevTask{}, existing.Tasks...), r.Tasks...)
+	for _, e := range src.tasks {
+		dt := e.value
+		if taskIDs[e.key()] == dt.ID && !unresolved[dt.ID] {
+			checkEntry(src, e.line, dt.CheckEntry(tasks...))
+		}
+	}
+
+	// Sprints, matched by ID or by goal and start date
+	knownSprints := make(map[string]bool)
+	sprintKeys := make(map[string]string)
+	sprintKey := func(s *models.Sprint) string {
+		return normalize(s.Goal) + "\x00" + s.StartDate.Format(DateLayout)
+	}
+	for _, s := range existing.Sprints {
+		knownSprints[s.ID] = true
+		sprintKeys[sprintKey(s)] = s.ID
+	}
+	for _, e := range src.sprints {
+		s, ok := e.value, false
+		if s.StartDate.IsZero() || s.EndDate.IsZero() {
+			src.problem(e.line, "start", "start and end dates are required")
+			continue
+		}
+		if s.EndDate.Before(s.StartDate) {
+			src.problem(e.line, "end", "is before the start")
+			continue
+		}
+		switch {
+		case knownSprints[e.id]:
+			duplicate(models.KindSprint, e.line, e.id, s.Goal, e.id)
+			continue
+		case sprintKeys[sprintKey(s)] != "":
+			duplicate(models.KindSprint, e.line, e.id, s.Goal, sprintKeys[sprintKey(s)])
+			continue
+		}
+		for i, ref := range s.Committed {
+			if s.Committed[i], ok = story(ref); !ok {
+				src.problem(e.line, "committed", "unknown story %q", ref)
+			}
+		}
+		for i, ref := range s.Completed {
+			if s.Completed[i], ok = story(ref); !ok {
+				src.problem(e.line, "completed", "unknown story %q", ref)
+			}
+		}
+		checkEntry(src, e.line, s.CheckEntry())
+		sprintKeys[sprintKey(s)] = s.ID
+		if e.id != "" {
+			r.IDs[e.id] = s.ID
+		}
+		r.Sprints = append(r.Sprints, s)
+	}
+
+	r.Problems = append([]Problem{}, src.problems...)
+	sort.SliceStable(r.Problems, func(i, j int) bool { return r.Problems[i].Line < r.Problems[j].Line })
+	if len(r.Problems) > 0 {
+		return r, fmt.Errorf("%w: %d problems", ErrInvalidImport, len(r.Problems))
+	}
+	if opts.DryRun || opts.Recorder == nil {
+		return r, nil
+	}
+	for _, us := range r.Stories {
+		us.Track(opts.Recorder)
+	}
+	for _, dt := range r.Tasks {
+		dt.Track(opts.Recorder)
+	}
+	for _, s := range r.Sprints {
+		s.Track(opts.Recorder)
+	}
+	r.Recorded = true
+	return r, nil
+}
+
+// checkEntry reports an entity whose status fails its entry guard as a problem with the status
+func checkEntry(src *source, line int, err error) {
+	var te *models.TransitionError
+	if errors.As(err, &te) {
+		src.problem(line, "status", "%s", te.Reason)
+	}
+}
+
+// known returns the IDs found in the set
+func known(ids []string, set map[string]bool) []string {
+	var out []string
+	for _, id := range ids {
+		if set[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
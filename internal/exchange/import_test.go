@@ -0,0 +1,162 @@
+package exchange
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+// storiesCSV holds two new stories, one matching an existing title and one repeated
+const storiesCSV = `id,title,points,status,criteria
+S-1,Sign in with email,5,Ready,User can sign in with a valid password
+S-2,Reset password,3,Draft,
+S-3,  export REPORTS ,2,Draft,
+S-4,Sign in with Email,8,Draft,
+`
+
+func TestImportDuplicatesAndRemapping(t *testing.T) {
+	existing := &Backlog{Stories: []*models.UserStory{{ID: "story-old", Title: "Export reports"}}}
+	events := store.New()
+
+	result, err := ImportCSV(strings.NewReader(storiesCSV), models.KindStory, nil, Options{DryRun: true, Existing: existing, Recorder: events})
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	// Check that matching titles are duplicates pointing at the story they match
+	if len(result.Stories) != 2 || len(result.Duplicates) != 2 {
+		t.Fatalf("Expected 2 stories and 2 duplicates, got %s", result.Summary())
+	}
+	if d := result.Duplicates[0]; d.SourceID != "S-3" || d.ExistingID != "story-old" || d.Line != 4 {
+		t.Errorf("Expected S-3 on line 4 to duplicate story-old, got %+v", d)
+	}
+	if d := result.Duplicates[1]; d.SourceID != "S-4" || d.ExistingID != result.IDs["S-1"] {
+		t.Errorf("Expected S-4 to duplicate the imported S-1, got %+v", d)
+	}
+
+	// Check that source IDs map to fresh IDs
+	if id := result.IDs["S-1"]; id != result.Stories[0].ID || !strings.HasPrefix(id, "story-") {
+		t.Errorf("Expected S-1 to map to a new story ID, got %q", id)
+	}
+	if result.Stories[0].Status != models.StoryReady || result.Stories[0].Estimate.Points != 5 {
+		t.Errorf("Expected a Ready story of 5 points, got %+v", result.Stories[0])
+	}
+
+	// Check that a dry run records nothing
+	if result.Recorded || len(events.Events()) != 0 {
+		t.Errorf("Expected nothing recorded on a dry run, got %d events", len(events.Events()))
+	}
+}
+
+func TestImportAcrossFiles(t *testing.T) {
+	events := store.New()
+	stories, err := ImportCSV(strings.NewReader(storiesCSV), models.KindStory, nil, Options{Recorder: events})
+	if err != nil {
+		t.Fatalf("Failed to import stories: %v", err)
+	}
+
+	// Check that the stories were recorded
+	if !stories.Recorded || len(events.State().Stories) != 3 {
+		t.Fatalf("Expected 3 recorded stories, got %d", len(events.State().Stories))
+	}
+
+	// Check that tasks resolve stories and each other through the earlier IDs
+	tasks := `id,story_id,title,dependencies
+T-1,S-1,Build the form,
+T-2,S-4,Call the API,T-1
+`
+	existing := FromSnapshot(events.State())
+	result, err := ImportCSV(strings.NewReader(tasks), models.KindTask, nil, Options{Existing: existing, IDs: stories.IDs, Recorder: events})
+	if err != nil {
+		t.Fatalf("Failed to import tasks: %v", err)
+	}
+	if len(result.Tasks) != 2 || result.Tasks[1].StoryID != stories.IDs["S-1"] || result.Tasks[1].Dependencies[0] != result.IDs["T-1"] {
+		t.Errorf("Expected both tasks on S-1's story with T-2 depending on T-1, got %+v", result.Tasks)
+	}
+	if len(events.State().Tasks) != 2 {
+		t.Errorf("Expected 2 recorded tasks, got %d", len(events.State().Tasks))
+	}
+}
+
+func TestImportProblems(t *testing.T) {
+	events := store.New()
+	input := `title,status,value,points
+Sign in,Started,5,3
+,Draft,5,3
+Reset password,Draft,11,3
+Export reports,Ready,5,3
+`
+	result, err := ImportCSV(strings.NewReader(input), models.KindStory, nil, Options{Recorder: events})
+
+	// Check that every problem is reported by line and nothing is recorded
+	if !errors.Is(err, ErrInvalidImport) {
+		t.Fatalf("Expected ErrInvalidImport, got %v", err)
+	}
+	want := []string{
+		`line 2: status: "Started" is not one of Draft, Ready, InProgress, Done`,
+		"line 3: title: is required",
+		"line 4: value: 11 is outside 1-10",
+		"line 5: status: ready requires acceptance criteria",
+	}
+	if len(result.Problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %v", len(want), result.Problems)
+	}
+	for i, p := range result.Problems {
+		if p.String() != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], p.String())
+		}
+	}
+	if result.Recorded || len(events.Events()) != 0 {
+		t.Errorf("Expected nothing recorded, got %d events", len(events.Events()))
+	}
+
+	// Check that dependency cycles are problems on the line closing them
+	stories, err := ImportCSV(strings.NewReader("id,title\nS-1,Sign in\n"), models.KindStory, nil, Options{Recorder: events})
+	if err != nil {
+		t.Fatalf("Failed to import stories: %v", err)
+	}
+	tasks := `id,story_id,title,dependencies
+T-1,S-1,Build the form,T-3
+T-2,S-1,Call the API,T-1
+T-3,S-1,Check the form,T-2
+T-4,S-1,Deploy,T-4
+`
+	before := len(events.Events())
+	result, err = ImportCSV(strings.NewReader(tasks), models.KindTask, nil, Options{Existing: FromSnapshot(events.State()), IDs: stories.IDs, Recorder: events})
+	if !errors.Is(err, ErrInvalidImport) || len(result.Problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", result.Problems)
+	}
+	if p := result.Problems[0]; p.Line != 4 || p.String() != `line 4: dependencies: "T-2" closes a dependency cycle` {
+		t.Errorf("Expected the cycle closed on line 4, got %q", p.String())
+	}
+	if p := result.Problems[1]; p.Line != 5 {
+		t.Errorf("Expected the self-dependency on line 5, got %q", p.String())
+	}
+	if len(events.Events()) != before {
+		t.Errorf("Expected no tasks recorded, got %d new events", len(events.Events())-before)
+	}
+
+	// Check that tasks done before their dependencies and active sprints without stories are problems
+	tasks = `id,story_id,title,dependencies,status
+T-1,S-1,Build the form,,InProgress
+T-2,S-1,Call the API,T-1,Done
+`
+	result, err = ImportCSV(strings.NewReader(tasks), models.KindTask, nil, Options{Existing: FromSnapshot(events.State()), IDs: stories.IDs})
+	if !errors.Is(err, ErrInvalidImport) || len(result.Problems) != 1 || result.Problems[0].Line != 3 || result.Problems[0].Field != "status" {
+		t.Errorf("Expected the Done task on line 3 to be a problem, got %v", result.Problems)
+	}
+	sprints := "goal,status,start,end\nShip sign in,Active,2024-03-04,2024-03-15\n"
+	result, err = ImportCSV(strings.NewReader(sprints), models.KindSprint, nil, Options{})
+	if !errors.Is(err, ErrInvalidImport) || len(result.Problems) != 1 || result.Problems[0].String() != "line 2: status: active requires committed stories" {
+		t.Errorf("Expected the empty Active sprint to be a problem, got %v", result.Problems)
+	}
+
+	// Check that tasks of unknown stories are problems
+	_, err = ImportCSV(strings.NewReader("story_id,title\nS-9,Orphan\n"), models.KindTask, nil, Options{})
+	if !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("Expected ErrInvalidImport for an unknown story, got %v", err)
+	}
+}
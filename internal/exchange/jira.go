@@ -0,0 +1,208 @@
+package exchange
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// JiraTimeLayout is the format of dates in Jira issue JSON
+const JiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Jira custom fields used by Jira Cloud for story points and sprints
+const (
+	DefaultStoryPointsField = "customfield_10016"
+	DefaultSprintField      = "customfield_10020"
+)
+
+// JiraOptions configure the Jira export
+type JiraOptions struct {
+	ProjectKey       string // Prefix of issue keys, "EGT" when empty
+	StoryPointsField string // Custom field for story points, DefaultStoryPointsField when empty
+	SprintField      string // Custom field for sprints, DefaultSprintField when empty
+}
+
+// jiraStatuses are the Jira workflow statuses of stories and tasks; both share InProgress and Done
+var jiraStatuses = map[string]string{
+	string(models.StoryDraft):      "Backlog",
+	string(models.StoryReady):      "Selected for Development",
+	string(models.StoryInProgress): "In Progress",
+	string(models.StoryDone):       "Done",
+	string(models.TaskTodo):        "To Do",
+	string(models.TaskBlocked):     "Blocked",
+}
+
+// jiraSprintStates are the Jira states of sprints
+var jiraSprintStates = map[models.SprintStatus]string{
+	models.SprintPlanned: "future",
+	models.SprintActive:  "active",
+	models.SprintClosed:  "closed",
+}
+
+// JiraName is a Jira field holding a named value, as issue types, statuses and priorities
+type JiraName struct {
+	Name string `json:"name"`
+}
+
+// JiraKey is a Jira reference to another issue
+type JiraKey struct {
+	Key string `json:"key"`
+}
+
+// JiraSprint is a sprint as listed in an issue's sprint field
+type JiraSprint struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Goal      string `json:"goal,omitempty"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+}
+
+// JiraIssue is one issue of a Jira export
+type JiraIssue struct {
+	Key    string         `json:"key"`
+	Fields map[string]any `json:"fields"`
+}
+
+// JiraExport is the document of a Jira export
+type JiraExport struct {
+	Issues []JiraIssue `json:"issues"`
+}
+
+// ExportJira converts the backlog to Jira issues: stories as Story issues with their
+// points and sprints, tasks as Sub-tasks of their story. Keys are numbered in backlog
+// order, and each issue's original ID is kept as a label.
+func ExportJira(b *Backlog, opts JiraOptions) *JiraExport {
+	if opts.ProjectKey == "" {
+		opts.ProjectKey = "EGT"
+	}
+	if opts.StoryPointsField == "" {
+		opts.StoryPointsField = DefaultStoryPointsField
+	}
+	if opts.SprintField == "" {
+		opts.SprintField = DefaultSprintField
+	}
+
+	keys := make(map[string]string)
+	for _, us := range b.Stories {
+		keys[us.ID] = opts.ProjectKey + "-" + strconv.Itoa(len(keys)+1)
+	}
+	for _, dt := range b.Tasks {
+		if _, ok := keys[dt.StoryID]; ok {
+			keys[dt.ID] = opts.ProjectKey + "-" + strconv.Itoa(len(keys)+1)
+		}
+	}
+	sprints := make(map[string][]JiraSprint)
+	for i, s := range b.Sprints {
+		sprint := JiraSprint{ID: i + 1, Name: s.ID, State: jiraSprintStates[s.Status], Goal: s.Goal,
+			StartDate: jiraTime(s.StartDate), EndDate: jiraTime(s.EndDate)}
+		for _, id := range s.Committed {
+			sprints[id] = append(sprints[id], sprint)
+		}
+	}
+
+	export := &JiraExport{Issues: []JiraIssue{}}
+	for _, us := range b.Stories {
+		fields := map[string]any{
+			"project":             JiraKey{Key: opts.ProjectKey},
+			"issuetype":           JiraName{Name: "Story"},
+			"summary":             us.Title,
+			"description":         jiraDescription(us),
+			"status":              JiraName{Name: jiraStatuses[string(us.Status)]},
+			"priority":            JiraName{Name: string(us.Priority)},
+			"labels":              jiraLabels(us.ID, strings.ToLower(string(us.MoSCoW))),
+			"created":             jiraTime(us.CreatedAt),
+			"updated":             jiraTime(us.UpdatedAt),
+			opts.StoryPointsField: storyPoints(us),
+		}
+		if list := sprints[us.ID]; len(list) > 0 {
+			fields[opts.SprintField] = list
+		}
+		if parent, ok := keys[us.ParentID]; ok {
+			fields["issuelinks"] = []map[string]any{{
+				"type":         JiraName{Name: "Relates"},
+				"outwardIssue": JiraKey{Key: parent},
+			}}
+		}
+		export.Issues = append(export.Issues, JiraIssue{Key: keys[us.ID], Fields: fields})
+	}
+	for _, dt := range b.Tasks {
+		parent, ok := keys[dt.StoryID]
+		if !ok {
+			continue
+		}
+		fields := map[string]any{
+			"project":              JiraKey{Key: opts.ProjectKey},
+			"issuetype":            JiraName{Name: "Sub-task"},
+			"parent":               JiraKey{Key: parent},
+			"summary":              dt.Title,
+			"status":               JiraName{Name: jiraStatuses[string(dt.Status)]},
+			"labels":               jiraLabels(dt.ID, strings.ToLower(string(dt.Type))),
+			"timeoriginalestimate": int64(dt.Estimate.Seconds()),
+			"created":              jiraTime(dt.CreatedAt),
+			"updated":              jiraTime(dt.UpdatedAt),
+		}
+		if dt.Assignee != "" {
+			fields["assignee"] = JiraName{Name: dt.Assignee}
+		}
+		if len(dt.Dependencies) > 0 {
+			var links []map[string]any
+			for _, dep := range dt.Dependencies {
+				if key, ok := keys[dep]; ok {
+					links = append(links, map[string]any{"type": JiraName{Name: "Blocks"}, "inwardIssue": JiraKey{Key: key}})
+				}
+			}
+			if len(links) > 0 {
+				fields["issuelinks"] = links
+			}
+		}
+		export.Issues = append(export.Issues, JiraIssue{Key: keys[dt.ID], Fields: fields})
+	}
+	return export
+}
+
+// WriteJira writes the backlog as indented Jira issue JSON
+func WriteJira(w io.Writer, b *Backlog, opts JiraOptions) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ExportJira(b, opts))
+}
+
+// jiraDescription is the story's description followed by its acceptance criteria
+func jiraDescription(us *models.UserStory) string {
+	description := us.Description
+	if len(us.AcceptanceCriteria) > 0 {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Acceptance criteria:"
+		for _, c := range us.AcceptanceCriteria {
+			description += "\n* " + c
+		}
+	}
+	return description
+}
+
+// jiraLabels returns the non-empty labels
+func jiraLabels(labels ...string) []string {
+	out := []string{}
+	for _, l := range labels {
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// jiraTime formats a time for Jira, or "" for the zero time
+func jiraTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(JiraTimeLayout)
+}
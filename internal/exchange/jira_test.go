@@ -0,0 +1,78 @@
+package exchange
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+func TestExportJira(t *testing.T) {
+	events := store.New()
+	us := models.NewUserStory("Checkout", "Pay for the cart")
+	us.AddAcceptanceCriterion("Pays by card")
+	us.SetEstimate(5)
+	us.Track(events)
+	build := models.NewDevTask(us.ID, "Build the form", "dev-1")
+	build.Track(events)
+	check := models.NewDevTask(us.ID, "Check the form", "")
+	check.Track(events)
+	check.AddDependency(build.ID)
+	sprint := models.NewSprint("Ship checkout", time.Date(2025, 9, 22, 9, 0, 0, 0, time.UTC), time.Date(2025, 10, 3, 17, 0, 0, 0, time.UTC))
+	sprint.Track(events)
+	sprint.AddCommittedStory(us.ID)
+
+	export := ExportJira(FromSnapshot(events.State()), JiraOptions{ProjectKey: "SHOP"})
+
+	// Check the keys and issue types
+	if len(export.Issues) != 3 || export.Issues[0].Key != "SHOP-1" || export.Issues[2].Key != "SHOP-3" {
+		t.Fatalf("Expected SHOP-1 to SHOP-3, got %+v", export.Issues)
+	}
+	story, sub := export.Issues[0].Fields, export.Issues[2].Fields
+	if story["issuetype"] != (JiraName{Name: "Story"}) || sub["issuetype"] != (JiraName{Name: "Sub-task"}) || sub["parent"] != (JiraKey{Key: "SHOP-1"}) {
+		t.Errorf("Expected a story with sub-tasks, got %v and %v", story, sub)
+	}
+
+	// Check story points, status, sprint and the criteria in the description
+	if story[DefaultStoryPointsField] != 5 || story["status"] != (JiraName{Name: "Backlog"}) {
+		t.Errorf("Expected 5 points in Backlog, got %v", story)
+	}
+	if sprints, ok := story[DefaultSprintField].([]JiraSprint); !ok || sprints[0].State != "future" || sprints[0].StartDate != "2025-09-22T09:00:00.000+0000" {
+		t.Errorf("Expected a future sprint from 2025-09-22, got %v", story[DefaultSprintField])
+	}
+	if story["description"] != "Pay for the cart\n\nAcceptance criteria:\n* Pays by card" {
+		t.Errorf("Expected the criteria in the description, got %q", story["description"])
+	}
+
+	// Check the estimate in seconds and the dependency as a link
+	if sub["timeoriginalestimate"] != int64(4*3600) {
+		t.Errorf("Expected a 4 hour estimate, got %v", sub["timeoriginalestimate"])
+	}
+	links, ok := sub["issuelinks"].([]map[string]any)
+	if !ok || links[0]["inwardIssue"] != (JiraKey{Key: "SHOP-2"}) {
+		t.Errorf("Expected SHOP-3 to be blocked by SHOP-2, got %v", sub["issuelinks"])
+	}
+
+	// Check the JSON document
+	var out strings.Builder
+	if err := WriteJira(&out, FromSnapshot(events.State()), JiraOptions{}); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+	var decoded struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if len(decoded.Issues) != 3 || decoded.Issues[0].Key != "EGT-1" || decoded.Issues[0].Fields.Summary != "Checkout" {
+		t.Errorf("Expected EGT keys with summaries, got %+v", decoded.Issues)
+	}
+}
@@ -0,0 +1,145 @@
+package exchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"egodteam/internal/data/models"
+)
+
+// Markdown checklists hold one story per top-level item, as "- [ ] Title (5 points)",
+// with its acceptance criteria as nested plain items and its tasks as nested checklist
+// items, as "  - [x] Title @assignee". IDs follow in an HTML comment at the end of the item.
+// A checked story is Done and an unchecked one Draft; a checked task is Done and an
+// unchecked one Todo. Plain items nested deeper than the first level are notes and, like
+// other lines, are ignored, so a checklist can sit in any document.
+var (
+	checkItem  = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] (.*)$`)
+	plainItem  = regexp.MustCompile(`^(\s+)[-*+] (.*)$`)
+	sourceID   = regexp.MustCompile(`\s*<!--\s*(\S+)\s*-->$`)
+	pointsNote = regexp.MustCompile(`\s*\((\d+) points?\)$`)
+	assigneeAt = regexp.MustCompile(`\s+@(\S+)$`)
+)
+
+// ExportMarkdown writes the backlog's stories with their criteria and tasks as a checklist
+// under the given heading. IDs are kept in HTML comments so the checklist can be re-imported.
+func ExportMarkdown(w io.Writer, b *Backlog, heading string) error {
+	out := bufio.NewWriter(w)
+	if heading != "" {
+		fmt.Fprintf(out, "# %s\n\n", heading)
+	}
+	for _, us := range b.Stories {
+		fmt.Fprintf(out, "- [%s] %s", box(us.Status == models.StoryDone), oneLine(us.Title))
+		if points := storyPoints(us); points > 0 {
+			fmt.Fprintf(out, " (%d %s)", points, plural(points, "point"))
+		}
+		fmt.Fprintf(out, " <!-- %s -->\n", us.ID)
+		for _, criterion := range us.AcceptanceCriteria {
+			fmt.Fprintf(out, "  - %s\n", oneLine(criterion))
+		}
+		for _, dt := range b.tasksOf(us.ID) {
+			fmt.Fprintf(out, "  - [%s] %s", box(dt.Status == models.TaskDone), oneLine(dt.Title))
+			if dt.Assignee != "" {
+				fmt.Fprintf(out, " @%s", dt.Assignee)
+			}
+			fmt.Fprintf(out, " <!-- %s -->\n", dt.ID)
+		}
+	}
+	return out.Flush()
+}
+
+// ImportMarkdown reads stories and tasks from a Markdown checklist and imports them
+func ImportMarkdown(r io.Reader, opts Options) (*Result, error) {
+	src := &source{}
+	story := -1  // Index of the story the following items belong to
+	indent := "" // Indentation of the items directly below the story
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t")
+		if m := checkItem.FindStringSubmatch(text); m != nil {
+			title, id := cut(m[3], sourceID)
+			checked := m[2] != " "
+			if m[1] == "" {
+				us := models.NewUserStory("", "")
+				if checked {
+					us.Status = models.StoryDone
+				}
+				var points string
+				if title, points = cut(title, pointsNote); points != "" {
+					n, _ := strconv.Atoi(points)
+					us.Estimate = &models.StoryEstimate{Points: n}
+				}
+				us.Title = title
+				src.stories = append(src.stories, entry[*models.UserStory]{line: line, id: id, value: us})
+				story = len(src.stories) - 1
+				indent = ""
+				continue
+			}
+			if story < 0 {
+				src.problem(line, "", "task outside a story")
+				continue
+			}
+			if indent == "" {
+				indent = m[1]
+			}
+			title, assignee := cut(title, assigneeAt)
+			dt := models.NewDevTask(src.stories[story].key(), title, assignee)
+			if checked {
+				dt.Status = models.TaskDone
+			}
+			src.tasks = append(src.tasks, entry[*models.DevTask]{line: line, id: id, value: dt})
+			continue
+		}
+		if m := plainItem.FindStringSubmatch(text); m != nil && story >= 0 {
+			if indent == "" {
+				indent = m[1]
+			}
+			if len(m[1]) <= len(indent) {
+				us := src.stories[story].value
+				us.AcceptanceCriteria = append(us.AcceptanceCriteria, strings.TrimSpace(m[2]))
+			}
+			continue // Deeper items are notes on the item above
+		}
+		if strings.TrimSpace(text) != "" && !strings.HasPrefix(text, " ") && !strings.HasPrefix(text, "\t") {
+			story = -1 // Criteria and tasks only follow their story
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return apply(src, opts)
+}
+
+// cut removes the pattern's match from the end of text and returns its first group
+func cut(text string, pattern *regexp.Regexp) (string, string) {
+	m := pattern.FindStringSubmatchIndex(text)
+	if m == nil {
+		return strings.TrimSpace(text), ""
+	}
+	return strings.TrimSpace(text[:m[0]]), text[m[2]:m[3]]
+}
+
+// box returns the checklist mark
+func box(checked bool) string {
+	if checked {
+		return "x"
+	}
+	return " "
+}
+
+// plural appends an s to the word unless n is 1
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+// oneLine joins the lines of text so it fits a list item
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
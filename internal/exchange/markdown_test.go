@@ -0,0 +1,106 @@
+package exchange
+
+import (
+	"strings"
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+// checklist is a backlog kept as notes
+const checklist = `# Release 1
+
+Notes before the list.
+
+- [x] Sign in with email (5 points)
+  - A wrong password shows an error
+  - [x] Build the form @dev-1
+  - [ ] Call the API
+- [ ] Reset password (1 point) <!-- PW-1 -->
+
+Loose notes
+  - [ ] Not a task of any story
+`
+
+func TestImportMarkdown(t *testing.T) {
+	result, err := ImportMarkdown(strings.NewReader(checklist), Options{DryRun: true})
+
+	// Check that the task after loose text is reported on its line
+	if err == nil || len(result.Problems) != 1 || result.Problems[0].Line != 12 {
+		t.Fatalf("Expected one problem on line 12, got %v", result.Problems)
+	}
+
+	// Check the stories with their points, status and criteria
+	if len(result.Stories) != 2 {
+		t.Fatalf("Expected 2 stories, got %d", len(result.Stories))
+	}
+	signIn, reset := result.Stories[0], result.Stories[1]
+	if signIn.Title != "Sign in with email" || signIn.Status != models.StoryDone || signIn.Estimate.Points != 5 {
+		t.Errorf("Expected a Done story of 5 points, got %+v", signIn)
+	}
+	if len(signIn.AcceptanceCriteria) != 1 || signIn.AcceptanceCriteria[0] != "A wrong password shows an error" {
+		t.Errorf("Expected one criterion, got %v", signIn.AcceptanceCriteria)
+	}
+	if reset.Status != models.StoryDraft || reset.Estimate.Points != 1 || result.IDs["PW-1"] != reset.ID {
+		t.Errorf("Expected a Draft story of 1 point mapped from PW-1, got %+v", reset)
+	}
+
+	// Check the tasks with their assignee and status
+	if len(result.Tasks) != 2 || result.Tasks[0].StoryID != signIn.ID || result.Tasks[0].Assignee != "dev-1" || result.Tasks[0].Status != models.TaskDone {
+		t.Errorf("Expected a Done task for dev-1 on the first story, got %+v", result.Tasks)
+	}
+	if result.Tasks[1].Title != "Call the API" || result.Tasks[1].Status != models.TaskTodo {
+		t.Errorf("Expected a Todo task, got %+v", result.Tasks[1])
+	}
+}
+
+func TestImportMarkdownNotes(t *testing.T) {
+	input := "- [ ] Sign in\n" +
+		"    - Shows an error\n" +
+		"    - [ ] Build the form\n" +
+		"        - Reuse the design system\n" +
+		"            - Ask the designers first\n"
+	result, err := ImportMarkdown(strings.NewReader(input), Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	// Check that only items at the first nesting level are criteria
+	if criteria := result.Stories[0].AcceptanceCriteria; len(criteria) != 1 || criteria[0] != "Shows an error" {
+		t.Errorf("Expected one criterion, got %v", criteria)
+	}
+	if len(result.Tasks) != 1 {
+		t.Errorf("Expected one task, got %+v", result.Tasks)
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	us := models.NewUserStory("Checkout", "")
+	us.AddAcceptanceCriterion("Pays by card")
+	us.SetEstimate(3)
+	dt := models.NewDevTask(us.ID, "Build the form", "dev-1")
+	b := &Backlog{Stories: []*models.UserStory{us}, Tasks: []*models.DevTask{dt}}
+
+	var out strings.Builder
+	if err := ExportMarkdown(&out, b, "Backlog"); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	// Check the checklist layout
+	want := "# Backlog\n\n" +
+		"- [ ] Checkout (3 points) <!-- " + us.ID + " -->\n" +
+		"  - Pays by card\n" +
+		"  - [ ] Build the form @dev-1 <!-- " + dt.ID + " -->\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+
+	// Check that importing it again only finds duplicates of the originals
+	result, err := ImportMarkdown(strings.NewReader(out.String()), Options{Existing: b})
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+	if len(result.Stories) != 0 || len(result.Tasks) != 0 || len(result.Duplicates) != 2 || result.IDs[dt.ID] != dt.ID {
+		t.Errorf("Expected 2 duplicates and nothing new, got %s", result.Summary())
+	}
+}
@@ -0,0 +1,128 @@
+package forecast
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// DefaultTrials is the number of simulations run when a Forecaster has no explicit count
+const DefaultTrials = 10000
+
+// maxSprints bounds a single simulation so zero-velocity histories terminate
+const maxSprints = 1000
+
+// Forecaster runs Monte Carlo simulations by sampling past sprint velocities
+type Forecaster struct {
+	History History
+	Trials  int
+	rng     *rand.Rand
+}
+
+// NewForecaster creates a forecaster over the given history; the seed makes results reproducible
+func NewForecaster(history History, seed int64) *Forecaster {
+	return &Forecaster{
+		History: history,
+		Trials:  DefaultTrials,
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Distribution is a sorted set of simulation outcomes
+type Distribution []int
+
+// Percentile returns the smallest outcome at or above the given fraction of simulations
+func (d Distribution) Percentile(p float64) int {
+	if len(d) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(d)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(d) {
+		i = len(d) - 1
+	}
+	return d[i]
+}
+
+// trials returns the configured number of simulations
+func (f *Forecaster) trials() int {
+	if f.Trials <= 0 {
+		return DefaultTrials
+	}
+	return f.Trials
+}
+
+// SprintsToComplete simulates how many sprints are needed to burn the given points
+func (f *Forecaster) SprintsToComplete(points int) (Distribution, error) {
+	if len(f.History) == 0 {
+		return nil, ErrNoHistory
+	}
+
+	d := make(Distribution, f.trials())
+	for i := range d {
+		left, sprints := points, 0
+		for left > 0 && sprints < maxSprints {
+			left -= f.History[f.rng.Intn(len(f.History))]
+			sprints++
+		}
+		d[i] = sprints
+	}
+	sort.Ints(d)
+
+	return d, nil
+}
+
+// SprintsFor answers "when will these stories be done?" as a number of sprints
+// reached in the given fraction of simulations
+func (f *Forecaster) SprintsFor(stories []*models.UserStory, confidence float64) (int, error) {
+	if confidence <= 0 || confidence >= 1 {
+		return 0, ErrInvalidConfidence
+	}
+
+	points := 0
+	for _, us := range stories {
+		if us.Estimate != nil {
+			points += us.Estimate.Points
+		}
+	}
+
+	d, err := f.SprintsToComplete(points)
+	if err != nil {
+		return 0, err
+	}
+	return d.Percentile(confidence), nil
+}
+
+// CompletionDate converts SprintsFor into the end date of the last sprint needed,
+// given the start of the next sprint and the sprint length
+func (f *Forecaster) CompletionDate(stories []*models.UserStory, confidence float64, nextStart time.Time, sprintLength time.Duration) (time.Time, error) {
+	sprints, err := f.SprintsFor(stories, confidence)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return nextStart.Add(time.Duration(sprints) * sprintLength), nil
+}
+
+// Commitment answers "how many points can we commit next sprint?": the velocity
+// that at least the given fraction of simulated sprints reach
+func (f *Forecaster) Commitment(confidence float64) (int, error) {
+	if confidence <= 0 || confidence >= 1 {
+		return 0, ErrInvalidConfidence
+	}
+	if len(f.History) == 0 {
+		return 0, ErrNoHistory
+	}
+
+	d := make(Distribution, f.trials())
+	for i := range d {
+		d[i] = f.History[f.rng.Intn(len(f.History))]
+	}
+	sort.Ints(d)
+
+	return d.Percentile(1 - confidence), nil
+}
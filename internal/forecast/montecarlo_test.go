@@ -0,0 +1,105 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+func TestSprintsToCompleteConstantVelocity(t *testing.T) {
+	f := NewForecaster(History{10, 10, 10}, 1)
+
+	// With a constant velocity every simulation needs the same number of sprints
+	d, err := f.SprintsToComplete(35)
+	if err != nil {
+		t.Fatalf("Failed to simulate: %v", err)
+	}
+	if d.Percentile(0.01) != 4 || d.Percentile(0.99) != 4 {
+		t.Errorf("Expected exactly 4 sprints, got %d to %d", d.Percentile(0.01), d.Percentile(0.99))
+	}
+}
+
+func TestSprintsToCompleteNoHistory(t *testing.T) {
+	f := NewForecaster(nil, 1)
+	if _, err := f.SprintsToComplete(10); err != ErrNoHistory {
+		t.Errorf("Expected error %v, got %v", ErrNoHistory, err)
+	}
+}
+
+func TestSprintsToCompleteZeroVelocity(t *testing.T) {
+	f := NewForecaster(History{0}, 1)
+	f.Trials = 10
+
+	// Simulations are bounded even if the team never completes anything
+	d, err := f.SprintsToComplete(10)
+	if err != nil {
+		t.Fatalf("Failed to simulate: %v", err)
+	}
+	if d.Percentile(0.5) != maxSprints {
+		t.Errorf("Expected %d sprints, got %d", maxSprints, d.Percentile(0.5))
+	}
+}
+
+func TestSprintsFor(t *testing.T) {
+	f := NewForecaster(History{5, 10, 15}, 42)
+
+	var stories []*models.UserStory
+	for i := 0; i < 4; i++ {
+		us := models.NewUserStory("Story", "")
+		us.SetEstimate(10)
+		stories = append(stories, us)
+	}
+
+	// Higher confidence never forecasts fewer sprints
+	p50, err := f.SprintsFor(stories, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to forecast: %v", err)
+	}
+	p85, _ := f.SprintsFor(stories, 0.85)
+	if p85 < p50 {
+		t.Errorf("Expected 85%% forecast (%d) to be at least the 50%% forecast (%d)", p85, p50)
+	}
+	if p50 < 3 || p85 > 8 {
+		t.Errorf("Expected forecasts between 3 and 8 sprints for 40 points, got %d and %d", p50, p85)
+	}
+
+	if _, err := f.SprintsFor(stories, 1.5); err != ErrInvalidConfidence {
+		t.Errorf("Expected error %v, got %v", ErrInvalidConfidence, err)
+	}
+}
+
+func TestCompletionDate(t *testing.T) {
+	f := NewForecaster(History{10}, 1)
+	us := models.NewUserStory("Story", "")
+	us.SetEstimate(25)
+
+	start := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	date, err := f.CompletionDate([]*models.UserStory{us}, 0.85, start, 14*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to forecast: %v", err)
+	}
+
+	// 25 points at 10 per sprint take three two-week sprints
+	if expected := start.AddDate(0, 0, 42); !date.Equal(expected) {
+		t.Errorf("Expected completion on %v, got %v", expected, date)
+	}
+}
+
+func TestCommitment(t *testing.T) {
+	f := NewForecaster(History{10, 20, 30, 40}, 7)
+
+	// At 85% confidence only the lowest velocity is safe to commit
+	points, err := f.Commitment(0.85)
+	if err != nil {
+		t.Fatalf("Failed to forecast: %v", err)
+	}
+	if points != 10 {
+		t.Errorf("Expected to commit 10 points, got %d", points)
+	}
+
+	// At 50% the commitment rises
+	if points, _ := f.Commitment(0.5); points < 20 {
+		t.Errorf("Expected to commit at least 20 points at 50%%, got %d", points)
+	}
+}
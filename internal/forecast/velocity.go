@@ -0,0 +1,82 @@
+// Package forecast computes velocity history and probabilistic delivery forecasts
+// in the agile team intelligent agent system.
+package forecast
+
+import (
+	"sort"
+
+	"egodteam/internal/data/models"
+)
+
+// forecastError implements the error interface
+type forecastError string
+
+func (e forecastError) Error() string {
+	return string(e)
+}
+
+// ErrNoHistory is returned when a forecast is requested without any past velocity
+var ErrNoHistory = forecastError("no velocity history")
+
+// ErrInvalidConfidence is returned when a confidence level is outside (0, 1)
+var ErrInvalidConfidence = forecastError("confidence must be between 0 and 1")
+
+// History is the velocity of past sprints in chronological order
+type History []int
+
+// NewHistory computes the velocity of each closed sprint from its completed story points,
+// ordered by EndDate. Planned and active sprints are ignored.
+func NewHistory(sprints []*models.Sprint, points models.PointsLookup) History {
+	closed := make([]*models.Sprint, 0, len(sprints))
+	for _, s := range sprints {
+		if s.Status == models.SprintClosed {
+			closed = append(closed, s)
+		}
+	}
+	sort.SliceStable(closed, func(i, j int) bool {
+		return closed[i].EndDate.Before(closed[j].EndDate)
+	})
+
+	h := make(History, len(closed))
+	for i, s := range closed {
+		h[i] = s.CompletedPoints(points)
+	}
+	return h
+}
+
+// Average returns the mean velocity, or 0 without history
+func (h History) Average() float64 {
+	if len(h) == 0 {
+		return 0
+	}
+	total := 0
+	for _, v := range h {
+		total += v
+	}
+	return float64(total) / float64(len(h))
+}
+
+// Rolling returns the average of each window of the given size ending at every sprint;
+// the first entries average over fewer sprints
+func (h History) Rolling(window int) []float64 {
+	if window <= 0 {
+		window = 1
+	}
+	averages := make([]float64, len(h))
+	for i := range h {
+		start := i - window + 1
+		if start < 0 {
+			start = 0
+		}
+		averages[i] = h[start : i+1].Average()
+	}
+	return averages
+}
+
+// Last returns the most recent n sprints of the history
+func (h History) Last(n int) History {
+	if n >= len(h) || n < 0 {
+		return h
+	}
+	return h[len(h)-n:]
+}
@@ -0,0 +1,79 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// newClosedSprint creates a closed sprint ending after the given number of weeks with completed stories
+func newClosedSprint(t *testing.T, weeks int, completed ...string) *models.Sprint {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*weeks)
+	s := models.NewSprint("Sprint", start, start.AddDate(0, 0, 7))
+	for _, id := range completed {
+		s.AddCommittedStory(id)
+		s.AddCompletedStory(id)
+	}
+	if err := s.SetStatus(models.SprintActive); err != nil {
+		t.Fatalf("Failed to start sprint: %v", err)
+	}
+	if err := s.SetStatus(models.SprintClosed); err != nil {
+		t.Fatalf("Failed to close sprint: %v", err)
+	}
+	return s
+}
+
+// storyPoints creates a lookup with fixed points per story ID
+func storyPoints(points map[string]int) models.PointsLookup {
+	return func(id string) int { return points[id] }
+}
+
+func TestNewHistory(t *testing.T) {
+	points := storyPoints(map[string]int{"a": 5, "b": 3, "c": 8, "d": 2})
+	open := models.NewSprint("Current", time.Now(), time.Now().Add(7*24*time.Hour))
+	sprints := []*models.Sprint{
+		newClosedSprint(t, 2, "c"),
+		newClosedSprint(t, 0, "a", "b"),
+		open,
+		newClosedSprint(t, 1, "d"),
+	}
+
+	// Check that closed sprints are ordered chronologically and open ones skipped
+	h := NewHistory(sprints, points)
+	expected := History{8, 2, 8}
+	if len(h) != len(expected) {
+		t.Fatalf("Expected history %v, got %v", expected, h)
+	}
+	for i := range expected {
+		if h[i] != expected[i] {
+			t.Errorf("Expected history %v, got %v", expected, h)
+			break
+		}
+	}
+}
+
+func TestHistoryAverages(t *testing.T) {
+	h := History{10, 20, 30, 40}
+
+	if avg := h.Average(); avg != 25 {
+		t.Errorf("Expected average 25, got %v", avg)
+	}
+	if avg := (History{}).Average(); avg != 0 {
+		t.Errorf("Expected average 0 without history, got %v", avg)
+	}
+
+	// Check the rolling averages over three sprints
+	rolling := h.Rolling(3)
+	expected := []float64{10, 15, 20, 30}
+	for i := range expected {
+		if rolling[i] != expected[i] {
+			t.Errorf("Expected rolling averages %v, got %v", expected, rolling)
+			break
+		}
+	}
+
+	if last := h.Last(2); len(last) != 2 || last[0] != 30 {
+		t.Errorf("Expected last two sprints [30 40], got %v", last)
+	}
+}
@@ -0,0 +1,238 @@
+// Package gherkin reads and writes user stories as Gherkin .feature files
+// in the agile team intelligent agent system.
+package gherkin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"egodteam/internal/data/models"
+)
+
+// gherkinError implements the error interface
+type gherkinError string
+
+func (e gherkinError) Error() string {
+	return string(e)
+}
+
+// ErrNoFeature is returned when a file has no Feature header
+var ErrNoFeature = gherkinError("missing Feature header")
+
+// ErrUnsupported is returned for Gherkin constructs stories cannot hold, such as Background and Rule
+var ErrUnsupported = gherkinError("unsupported Gherkin construct")
+
+// featureWords holds the Feature header per language
+var featureWords = map[string][]string{
+	models.LanguageEnglish: {"Feature", "Business Need", "Ability"},
+	models.LanguageChinese: {"功能"},
+}
+
+// scenarioWords start a new scenario block in any language
+var scenarioWords = []string{"Scenario Outline", "Scenario Template", "Scenario", "Example", "场景大纲", "剧本大纲", "场景", "剧本"}
+
+// unsupportedWords are sections a user story has no place for
+var unsupportedWords = []string{"Background", "Rule", "背景", "规则"}
+
+// Feature is a user story as a Gherkin feature
+type Feature struct {
+	Language    string
+	Title       string
+	Description []string
+	Scenarios   []models.Scenario
+}
+
+// FromStory converts a story into a feature; every acceptance criterion must be valid Gherkin
+func FromStory(us *models.UserStory) (*Feature, error) {
+	f := &Feature{Language: models.LanguageEnglish, Title: us.Title}
+	for _, line := range strings.Split(us.Description, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			f.Description = append(f.Description, line)
+		}
+	}
+
+	scenarios, errs := us.Scenarios()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("criterion %d of %s: %w", i+1, us.ID, err)
+		}
+	}
+	f.Scenarios = scenarios
+	if len(scenarios) > 0 {
+		f.Language = scenarios[0].Language
+	}
+	return f, nil
+}
+
+// Story creates a new user story from the feature, one acceptance criterion per scenario
+func (f *Feature) Story() *models.UserStory {
+	us := models.NewUserStory(f.Title, strings.Join(f.Description, "\n"))
+	for _, s := range f.Scenarios {
+		us.AddAcceptanceCriterion(s.String())
+	}
+	return us
+}
+
+// Write renders the feature as a .feature file
+func (f *Feature) Write(w io.Writer) error {
+	var b strings.Builder
+	header := featureWords[models.LanguageEnglish][0]
+	if f.Language == models.LanguageChinese {
+		fmt.Fprintf(&b, "# language: %s\n", models.LanguageChinese)
+		header = featureWords[models.LanguageChinese][0]
+	}
+	fmt.Fprintf(&b, "%s: %s\n", header, f.Title)
+	for _, line := range f.Description {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+
+	for _, s := range f.Scenarios {
+		text := s.String()
+		if s.Name == "" && !s.Outline {
+			// Unnamed criteria still need a header to stand on their own
+			text = scenarioHeader(s) + ":\n" + indent(text, "  ")
+		}
+		fmt.Fprintf(&b, "\n%s\n", indent(text, "  "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// scenarioHeader returns the scenario keyword in the scenario's language
+func scenarioHeader(s models.Scenario) string {
+	if s.Language == models.LanguageChinese {
+		return "场景"
+	}
+	return "Scenario"
+}
+
+// indent prefixes every non-empty line
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseFeature reads a .feature file holding one feature, validating every scenario
+func ParseFeature(r io.Reader) (*Feature, error) {
+	f := &Feature{Language: models.LanguageEnglish}
+	seenFeature := false
+	var blocks [][]string
+
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			if language, ok := languageComment(line); ok && !seenFeature {
+				f.Language = language
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		if title, ok := matchHeader(line, featureWords[models.LanguageEnglish], featureWords[models.LanguageChinese]); ok {
+			if seenFeature {
+				return nil, fmt.Errorf("line %d: %w: more than one Feature", number, ErrUnsupported)
+			}
+			f.Title = title
+			seenFeature = true
+			continue
+		}
+		if !seenFeature {
+			return nil, fmt.Errorf("line %d: %w", number, ErrNoFeature)
+		}
+		if _, ok := matchHeader(line, unsupportedWords); ok {
+			return nil, fmt.Errorf("line %d: %w: %s", number, ErrUnsupported, line)
+		}
+		if _, ok := matchHeader(line, scenarioWords); ok {
+			blocks = append(blocks, []string{line})
+			continue
+		}
+		if len(blocks) == 0 {
+			f.Description = append(f.Description, line)
+			continue
+		}
+		blocks[len(blocks)-1] = append(blocks[len(blocks)-1], line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !seenFeature {
+		return nil, ErrNoFeature
+	}
+
+	for i, block := range blocks {
+		s, err := models.ParseScenario(strings.Join(block, "\n"))
+		if err != nil {
+			return nil, fmt.Errorf("scenario %d: %w", i+1, err)
+		}
+		f.Scenarios = append(f.Scenarios, s)
+	}
+	return f, nil
+}
+
+// languageComment parses a "# language: xx" comment
+func languageComment(line string) (string, bool) {
+	rest := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+	if !strings.HasPrefix(rest, "language:") {
+		return "", false
+	}
+	language := strings.TrimSpace(strings.TrimPrefix(rest, "language:"))
+	if strings.HasPrefix(language, "zh") {
+		return models.LanguageChinese, true
+	}
+	return models.LanguageEnglish, true
+}
+
+// matchHeader recognizes "<word>:" headers and returns the text after the colon
+func matchHeader(line string, words ...[]string) (string, bool) {
+	for _, group := range words {
+		for _, word := range group {
+			if !strings.HasPrefix(line, word) {
+				continue
+			}
+			rest := strings.TrimSpace(line[len(word):])
+			for _, colon := range []string{":", "："} {
+				if strings.HasPrefix(rest, colon) {
+					return strings.TrimSpace(rest[len(colon):]), true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// ReadFeatureFile parses the .feature file at path
+func ReadFeatureFile(path string) (*Feature, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseFeature(file)
+}
+
+// WriteFeatureFile writes the feature to a .feature file at path
+func (f *Feature) WriteFeatureFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
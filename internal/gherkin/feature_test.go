@@ -0,0 +1,115 @@
+package gherkin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"egodteam/internal/data/models"
+)
+
+const loginFeature = `Feature: Login
+  As a user I want to log in so that I can see my orders
+
+  Scenario: Successful login
+    Given a registered user
+    When they submit valid credentials
+    Then they see the dashboard
+
+  Scenario:
+    Given a locked account
+    When they submit valid credentials
+    Then they see an error
+
+  Scenario Outline: Password rules
+    Given the password <password>
+    When the user registers
+    Then the result is <result>
+
+    Examples:
+      | password | result   |
+      | abc      | rejected |
+`
+
+func TestParseFeature(t *testing.T) {
+	f, err := ParseFeature(strings.NewReader(loginFeature))
+	if err != nil {
+		t.Fatalf("Failed to parse feature: %v", err)
+	}
+
+	// Check the header and scenarios
+	if f.Title != "Login" || len(f.Description) != 1 {
+		t.Errorf("Expected Login with one description line, got %q and %v", f.Title, f.Description)
+	}
+	if len(f.Scenarios) != 3 || !f.Scenarios[2].Outline || f.Scenarios[1].Name != "" {
+		t.Fatalf("Expected 3 scenarios with the last an outline, got %+v", f.Scenarios)
+	}
+
+	// Writing the feature gives back the same file
+	var b strings.Builder
+	if err := f.Write(&b); err != nil {
+		t.Fatalf("Failed to write feature: %v", err)
+	}
+	if b.String() != loginFeature {
+		t.Errorf("Expected round trip, got:\n%s", b.String())
+	}
+}
+
+func TestParseFeatureErrors(t *testing.T) {
+	cases := map[string]error{
+		"Scenario: x\n  Given a\n  When b\n  Then c":                         ErrNoFeature,
+		"Feature: x\nBackground:\n  Given a":                                 ErrUnsupported,
+		"Feature: x\nScenario: y\n  Then a\n  When b":                        models.ErrMalformedCriterion,
+		"Feature: x\nScenario: y\n  Given a\n  When b\n  Then c\nFeature: z": ErrUnsupported,
+	}
+	for text, expected := range cases {
+		if _, err := ParseFeature(strings.NewReader(text)); !errors.Is(err, expected) {
+			t.Errorf("Expected %q to fail with %v, got %v", text, expected, err)
+		}
+	}
+}
+
+func TestStoryRoundTrip(t *testing.T) {
+	us := models.NewUserStory("退出登录", "作为用户，我想要退出登录，以便保护账户安全")
+	us.AddAcceptanceCriterion("给定用户已登录，当用户点击退出，那么跳转到登录页")
+
+	f, err := FromStory(us)
+	if err != nil {
+		t.Fatalf("Failed to convert story: %v", err)
+	}
+
+	// Chinese criteria produce a Chinese feature file
+	path := filepath.Join(t.TempDir(), "logout.feature")
+	if err := f.WriteFeatureFile(path); err != nil {
+		t.Fatalf("Failed to write feature file: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read feature file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# language: zh-CN\n功能: 退出登录\n") || !strings.Contains(string(data), "    假如用户已登录\n") {
+		t.Errorf("Expected a zh-CN feature file, got:\n%s", data)
+	}
+
+	// Reading it back gives an equivalent story
+	read, err := ReadFeatureFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read feature: %v", err)
+	}
+	back := read.Story()
+	if back.Title != us.Title || back.Description != us.Description || len(back.AcceptanceCriteria) != 1 {
+		t.Fatalf("Expected the same story back, got %+v", back)
+	}
+	scenarios, errs := back.Scenarios()
+	if errs[0] != nil || scenarios[0].Steps[2].Text != "跳转到登录页" {
+		t.Errorf("Expected the criterion to survive, got %+v and %v", scenarios, errs)
+	}
+
+	// Malformed criteria cannot be exported
+	us.AddAcceptanceCriterion("Users can log out")
+	if _, err := FromStory(us); !errors.Is(err, models.ErrMalformedCriterion) {
+		t.Errorf("Expected error %v, got %v", models.ErrMalformedCriterion, err)
+	}
+}
@@ -0,0 +1,122 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// storyFields are the fields a story query can use; unestimated stories have 0 points
+var storyFields = map[string]field[*models.UserStory]{
+	"id":          {kindKeyword, func(us *models.UserStory) any { return us.ID }},
+	"title":       {kindText, func(us *models.UserStory) any { return us.Title }},
+	"description": {kindText, func(us *models.UserStory) any { return us.Description }},
+	"criteria":    {kindText, func(us *models.UserStory) any { return strings.Join(us.AcceptanceCriteria, "\n") }},
+	"status":      {kindKeyword, func(us *models.UserStory) any { return string(us.Status) }},
+	"priority":    {kindKeyword, func(us *models.UserStory) any { return string(us.Priority) }},
+	"moscow":      {kindKeyword, func(us *models.UserStory) any { return string(us.MoSCoW) }},
+	"epic":        {kindKeyword, func(us *models.UserStory) any { return us.EpicID }},
+	"parent":      {kindKeyword, func(us *models.UserStory) any { return us.ParentID }},
+	"points":      {kindNumber, func(us *models.UserStory) any { return float64(storyPoints(us)) }},
+	"value":       {kindNumber, func(us *models.UserStory) any { return float64(us.BusinessValue) }},
+	"rank":        {kindNumber, func(us *models.UserStory) any { return float64(us.Rank) }},
+	"created":     {kindDate, func(us *models.UserStory) any { return us.CreatedAt }},
+	"updated":     {kindDate, func(us *models.UserStory) any { return us.UpdatedAt }},
+}
+
+// taskFields are the fields a task query can use; estimate is in hours and tasks not yet
+// started or completed have the zero date
+var taskFields = map[string]field[*models.DevTask]{
+	"id":        {kindKeyword, func(dt *models.DevTask) any { return dt.ID }},
+	"title":     {kindText, func(dt *models.DevTask) any { return dt.Title }},
+	"story":     {kindKeyword, func(dt *models.DevTask) any { return dt.StoryID }},
+	"status":    {kindKeyword, func(dt *models.DevTask) any { return string(dt.Status) }},
+	"type":      {kindKeyword, func(dt *models.DevTask) any { return string(dt.Type) }},
+	"assignee":  {kindKeyword, func(dt *models.DevTask) any { return dt.Assignee }},
+	"estimate":  {kindNumber, func(dt *models.DevTask) any { return dt.Estimate.Hours() }},
+	"deps":      {kindNumber, func(dt *models.DevTask) any { return float64(len(dt.Dependencies)) }},
+	"created":   {kindDate, func(dt *models.DevTask) any { return dt.CreatedAt }},
+	"updated":   {kindDate, func(dt *models.DevTask) any { return dt.UpdatedAt }},
+	"started":   {kindDate, func(dt *models.DevTask) any { return timeOf(dt.StartedAt) }},
+	"completed": {kindDate, func(dt *models.DevTask) any { return timeOf(dt.CompletedAt) }},
+}
+
+// sprintFields are the fields a sprint query can use
+var sprintFields = map[string]field[*models.Sprint]{
+	"id":        {kindKeyword, func(s *models.Sprint) any { return s.ID }},
+	"goal":      {kindText, func(s *models.Sprint) any { return s.Goal }},
+	"status":    {kindKeyword, func(s *models.Sprint) any { return string(s.Status) }},
+	"velocity":  {kindNumber, func(s *models.Sprint) any { return float64(s.Velocity) }},
+	"committed": {kindNumber, func(s *models.Sprint) any { return float64(len(s.Committed)) }},
+	"completed": {kindNumber, func(s *models.Sprint) any { return float64(len(s.Completed)) }},
+	"start":     {kindDate, func(s *models.Sprint) any { return s.StartDate }},
+	"end":       {kindDate, func(s *models.Sprint) any { return s.EndDate }},
+}
+
+// storyPoints returns the story's points, 0 when unestimated
+func storyPoints(us *models.UserStory) int {
+	if us.Estimate == nil {
+		return 0
+	}
+	return us.Estimate.Points
+}
+
+// timeOf returns the time, or the zero time for nil
+func timeOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// Stories returns the stories matching the query in the query's order, or the input order
+func (q *Query) Stories(stories []*models.UserStory) ([]*models.UserStory, error) {
+	return evaluate(q, stories, storyFields, []string{"title", "description", "criteria"})
+}
+
+// Tasks returns the tasks matching the query in the query's order, or the input order
+func (q *Query) Tasks(tasks []*models.DevTask) ([]*models.DevTask, error) {
+	return evaluate(q, tasks, taskFields, []string{"title"})
+}
+
+// Sprints returns the sprints matching the query in the query's order, or the input order
+func (q *Query) Sprints(sprints []*models.Sprint) ([]*models.Sprint, error) {
+	return evaluate(q, sprints, sprintFields, []string{"goal"})
+}
+
+// evaluate filters and sorts the items; text names the fields bare search words look in
+func evaluate[T any](q *Query, items []T, fields map[string]field[T], text []string) ([]T, error) {
+	terms, err := compile(q, fields, text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, len(items))
+next:
+	for _, item := range items {
+		for _, t := range terms {
+			if !t.matches(item) {
+				continue next
+			}
+		}
+		result = append(result, item)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		for _, k := range q.Sort {
+			get := fields[k.Field].get
+			cmp := compareValues(get(result[i]), get(result[j]))
+			if cmp == 0 {
+				continue
+			}
+			if k.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// ids returns the IDs of the stories
+func ids(stories []*models.UserStory) []string {
+	var out []string
+	for _, us := range stories {
+		out = append(out, us.ID)
+	}
+	return out
+}
+
+// newStory creates a story with the given status, priority, value and points
+func newStory(id string, status models.StoryStatus, priority models.PriorityLevel, value, points int) *models.UserStory {
+	us := models.NewUserStory("Story "+id, "")
+	us.ID = id
+	us.Status = status
+	us.Priority = priority
+	us.BusinessValue = value
+	us.Estimate.Points = points
+	return us
+}
+
+func TestStories(t *testing.T) {
+	login := newStory("login", models.StoryReady, models.PriorityHigh, 8, 0)
+	login.Title = "用户登录"
+	login.AcceptanceCriteria = []string{"Given a user, when they log in with a password, then they see the dashboard"}
+	export := newStory("export", models.StoryReady, models.PriorityHigh, 5, 3)
+	search := newStory("search", models.StoryReady, models.PriorityLow, 9, 0)
+	search.Estimate = nil
+	done := newStory("done", models.StoryDone, models.PriorityHigh, 3, 0)
+	stories := []*models.UserStory{login, export, search, done}
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"status:Ready priority:High points:0", []string{"login"}},
+		{"status:ready points:0 sort:-value", []string{"search", "login"}},
+		{"-status:Done sort:points sort:-value", []string{"search", "login", "export"}},
+		{"value:>=5 value:<9", []string{"login", "export"}},
+		{"priority:low,HIGH points:>0", []string{"export"}},
+		{"登录", []string{"login"}},
+		{"PASSWORD", []string{"login"}},
+		{"created:>=2000-01-01 -title:export", []string{"login", "search", "done"}},
+	}
+	for _, c := range cases {
+		q, err := Parse(c.query)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", c.query, err)
+		}
+		got, err := q.Stories(stories)
+		if err != nil {
+			t.Fatalf("Failed to evaluate %q: %v", c.query, err)
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("Expected %q to return %v, got %v", c.query, c.want, ids(got))
+			continue
+		}
+		for i := range got {
+			if got[i].ID != c.want[i] {
+				t.Errorf("Expected %q to return %v, got %v", c.query, c.want, ids(got))
+				break
+			}
+		}
+	}
+}
+
+func TestTasksAndSprints(t *testing.T) {
+	tasks := []*models.DevTask{
+		{ID: "t1", Type: models.TaskTesting, Status: models.TaskBlocked, Assignee: "dev-2", Estimate: 2 * time.Hour},
+		{ID: "t2", Type: models.TaskTesting, Status: models.TaskBlocked, Assignee: "dev-1", Estimate: 4 * time.Hour},
+		{ID: "t3", Type: models.TaskDevelopment, Status: models.TaskBlocked, Assignee: "dev-2", Estimate: 8 * time.Hour},
+	}
+	q, _ := Parse("status:Blocked type:Testing assignee:dev-2")
+	got, err := q.Tasks(tasks)
+
+	// Check the blocked testing task of dev-2
+	if err != nil || len(got) != 1 || got[0].ID != "t1" {
+		t.Errorf("Expected only t1, got %v (%v)", got, err)
+	}
+
+	// Check numeric hours and sorting
+	q, _ = Parse("estimate:>2 sort:-estimate")
+	if got, _ := q.Tasks(tasks); len(got) != 2 || got[0].ID != "t3" {
+		t.Errorf("Expected t3 then t2, got %v", got)
+	}
+
+	sprints := []*models.Sprint{
+		{ID: "s1", Status: models.SprintClosed, StartDate: time.Date(2025, 9, 8, 9, 0, 0, 0, time.UTC)},
+		{ID: "s2", Status: models.SprintActive, StartDate: time.Date(2025, 9, 22, 9, 0, 0, 0, time.UTC)},
+	}
+	q, _ = Parse("start:2025-09-22")
+
+	// Check that a date matches the whole day
+	if got, _ := q.Sprints(sprints); len(got) != 1 || got[0].ID != "s2" {
+		t.Errorf("Expected s2, got %v", got)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	cases := map[string]error{
+		"owner:dev-1":       ErrUnknownField,
+		"sort:owner":        ErrUnknownField,
+		"points:many":       ErrInvalidValue,
+		"created:yesterday": ErrInvalidValue,
+		"status:>Ready":     ErrInvalidValue,
+	}
+	for input, want := range cases {
+		q, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", input, err)
+		}
+		if _, err := q.Stories(nil); !errors.Is(err, want) {
+			t.Errorf("Expected %v for %q, got %v", want, input, err)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Package query parses and evaluates filter expressions over stories, tasks and sprints,
+// such as "status:Ready priority:High points:0 sort:-value".
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// queryError implements the error interface
+type queryError string
+
+func (e queryError) Error() string {
+	return string(e)
+}
+
+// ErrSyntax is returned when a query cannot be parsed
+var ErrSyntax = queryError("query syntax error")
+
+// ErrUnknownField is returned when a query refers to a field the entity does not have
+var ErrUnknownField = queryError("unknown field")
+
+// ErrInvalidValue is returned when a value does not fit its field or operator
+var ErrInvalidValue = queryError("invalid value")
+
+// DateLayout is the format of date values, as in "created:>=2025-09-01"
+const DateLayout = "2006-01-02"
+
+// Op is the comparison of a term
+type Op string
+
+const (
+	OpEq Op = ":"
+	OpGt Op = ">"
+	OpGe Op = ">="
+	OpLt Op = "<"
+	OpLe Op = "<="
+)
+
+// Term is one condition of a query. A term without a field searches the entity's text.
+type Term struct {
+	Field  string
+	Op     Op
+	Values []string // Any value matches
+	Negate bool
+}
+
+func (t Term) String() string {
+	values := make([]string, len(t.Values))
+	for i, v := range t.Values {
+		values[i] = quote(v)
+	}
+	s := strings.Join(values, ",")
+	if t.Field != "" {
+		op := string(t.Op)
+		if t.Op != OpEq {
+			op = ":" + op
+		}
+		s = t.Field + op + s
+	}
+	if t.Negate {
+		s = "-" + s
+	}
+	return s
+}
+
+// SortKey orders results by a field
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+func (k SortKey) String() string {
+	if k.Descending {
+		return "sort:-" + k.Field
+	}
+	return "sort:" + k.Field
+}
+
+// Query is a parsed query; all terms must match
+type Query struct {
+	Terms []Term
+	Sort  []SortKey
+}
+
+// String renders the query back to its text form
+func (q *Query) String() string {
+	parts := make([]string, 0, len(q.Terms)+len(q.Sort))
+	for _, t := range q.Terms {
+		parts = append(parts, t.String())
+	}
+	for _, k := range q.Sort {
+		parts = append(parts, k.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// Parse parses a query made of space separated terms:
+//
+//	field:value          equal, case-insensitive; text fields match substrings
+//	field:a,b            any of the values
+//	field:>n field:<=n   compare numbers or dates (YYYY-MM-DD)
+//	-field:value         negate a term
+//	word "two words"     search the entity's text
+//	sort:field sort:-f   order results, descending with "-"
+func Parse(input string) (*Query, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	for _, token := range tokens {
+		if strings.EqualFold(token.field, "sort") {
+			if token.negate || token.op != OpEq || len(token.values) != 1 || token.values[0] == "" {
+				return nil, fmt.Errorf("%w: sort takes one field", ErrSyntax)
+			}
+			key := SortKey{Field: strings.ToLower(token.values[0])}
+			if strings.HasPrefix(key.Field, "-") {
+				key.Field = key.Field[1:]
+				key.Descending = true
+			}
+			q.Sort = append(q.Sort, key)
+			continue
+		}
+		q.Terms = append(q.Terms, Term{
+			Field:  strings.ToLower(token.field),
+			Op:     token.op,
+			Values: token.values,
+			Negate: token.negate,
+		})
+	}
+	return q, nil
+}
+
+// token is a term as written
+type token struct {
+	field  string
+	op     Op
+	values []string
+	negate bool
+}
+
+// tokenize splits the input into terms, honouring double quotes
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		t := token{op: OpEq}
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			t.negate = true
+			i++
+		}
+
+		// A field is a run of letters and underscores followed by a colon
+		j := i
+		for j < len(runes) && (unicode.IsLetter(runes[j]) || runes[j] == '_') {
+			j++
+		}
+		if j > i && j < len(runes) && runes[j] == ':' {
+			t.field = string(runes[i:j])
+			i = j + 1
+			for _, op := range []Op{OpGe, OpLe, OpGt, OpLt} {
+				if strings.HasPrefix(string(runes[i:]), string(op)) {
+					t.op = op
+					i += len(op)
+					break
+				}
+			}
+		}
+
+		values, next, err := readValues(runes, i, t.field != "")
+		if err != nil {
+			return nil, err
+		}
+		if t.field == "" && (len(values) == 0 || values[0] == "") {
+			return nil, fmt.Errorf("%w: empty term at %d", ErrSyntax, i)
+		}
+		t.values = values
+		tokens = append(tokens, t)
+		i = next
+	}
+	return tokens, nil
+}
+
+// readValues reads a value list up to the next space outside quotes. Field values are
+// separated by commas; a bare search word is kept whole.
+func readValues(runes []rune, i int, list bool) ([]string, int, error) {
+	var values []string
+	var b strings.Builder
+	for ; i < len(runes) && !unicode.IsSpace(runes[i]); i++ {
+		switch {
+		case runes[i] == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, 0, fmt.Errorf("%w: unterminated quote", ErrSyntax)
+			}
+			b.WriteString(string(runes[i+1 : end]))
+			i = end
+		case runes[i] == ',' && list:
+			values = append(values, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+	return append(values, b.String()), i, nil
+}
+
+// quote wraps values that would not survive tokenizing as-is
+func quote(v string) string {
+	if v == "" || strings.ContainsAny(v, " \t\n\",") {
+		return `"` + v + `"`
+	}
+	return v
+}
+
+// kind is how a field's values compare
+type kind int
+
+const (
+	kindKeyword kind = iota // Case-insensitive equality
+	kindText                // Case-insensitive substring
+	kindNumber
+	kindDate
+)
+
+// field reads one property of an entity
+type field[T any] struct {
+	kind kind
+	get  func(T) any // Returns string, float64 or time.Time per kind
+}
+
+// compiled is a term checked against an entity's fields, with parsed operands
+type compiled[T any] struct {
+	term    Term
+	fields  []field[T] // All text fields for a bare search term
+	numbers []float64
+	dates   []time.Time
+}
+
+// compile checks every term and sort key against the entity's fields
+func compile[T any](q *Query, fields map[string]field[T], text []string) ([]compiled[T], error) {
+	terms := make([]compiled[T], 0, len(q.Terms))
+	for _, t := range q.Terms {
+		c := compiled[T]{term: t}
+		if t.Field == "" {
+			for _, name := range text {
+				c.fields = append(c.fields, fields[name])
+			}
+			terms = append(terms, c)
+			continue
+		}
+
+		f, ok := fields[t.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, t.Field)
+		}
+		c.fields = []field[T]{f}
+		switch f.kind {
+		case kindNumber:
+			for _, v := range t.Values {
+				n, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s needs a number, got %q", ErrInvalidValue, t.Field, v)
+				}
+				c.numbers = append(c.numbers, n)
+			}
+		case kindDate:
+			for _, v := range t.Values {
+				d, err := time.Parse(DateLayout, v)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s needs a date like %s, got %q", ErrInvalidValue, t.Field, DateLayout, v)
+				}
+				c.dates = append(c.dates, d)
+			}
+		default:
+			if t.Op != OpEq {
+				return nil, fmt.Errorf("%w: %s cannot be compared with %s", ErrInvalidValue, t.Field, t.Op)
+			}
+		}
+		terms = append(terms, c)
+	}
+
+	for _, k := range q.Sort {
+		if _, ok := fields[k.Field]; !ok {
+			return nil, fmt.Errorf("%w: sort by %s", ErrUnknownField, k.Field)
+		}
+	}
+	return terms, nil
+}
+
+// matches reports whether the item satisfies the term
+func (c compiled[T]) matches(item T) bool {
+	return c.match(item) != c.term.Negate
+}
+
+func (c compiled[T]) match(item T) bool {
+	for _, f := range c.fields {
+		value := f.get(item)
+		for i, v := range c.term.Values {
+			var ok bool
+			switch f.kind {
+			case kindKeyword:
+				ok = strings.EqualFold(value.(string), v)
+			case kindText:
+				ok = strings.Contains(strings.ToLower(value.(string)), strings.ToLower(v))
+			case kindNumber:
+				ok = compareOp(c.term.Op, compareNumbers(value.(float64), c.numbers[i]))
+			case kindDate:
+				// Dates compare by day so "created:2025-09-22" matches the whole day
+				day := value.(time.Time)
+				day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+				ok = compareOp(c.term.Op, compareNumbers(float64(day.Unix()), float64(c.dates[i].Unix())))
+			}
+			if ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareNumbers returns -1, 0 or 1
+func compareNumbers(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareOp applies the operator to a comparison result
+func compareOp(op Op, cmp int) bool {
+	switch op {
+	case OpGt:
+		return cmp > 0
+	case OpGe:
+		return cmp >= 0
+	case OpLt:
+		return cmp < 0
+	case OpLe:
+		return cmp <= 0
+	}
+	return cmp == 0
+}
+
+// compareValues orders two values of the same field
+func compareValues(a, b any) int {
+	switch av := a.(type) {
+	case float64:
+		return compareNumbers(av, b.(float64))
+	case time.Time:
+		return av.Compare(b.(time.Time))
+	}
+	return strings.Compare(strings.ToLower(a.(string)), strings.ToLower(b.(string)))
+}
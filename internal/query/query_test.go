@@ -0,0 +1,47 @@
+package query
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`status:Ready,InProgress points:>=3 -assignee:dev-2 "two words" 登录 sort:-value`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	// Check terms and sort keys
+	if len(q.Terms) != 5 || len(q.Sort) != 1 {
+		t.Fatalf("Expected 5 terms and 1 sort key, got %+v", q)
+	}
+	if status := q.Terms[0]; status.Field != "status" || len(status.Values) != 2 || status.Values[1] != "InProgress" {
+		t.Errorf("Expected status with two values, got %+v", status)
+	}
+	if points := q.Terms[1]; points.Op != OpGe || points.Values[0] != "3" {
+		t.Errorf("Expected points >= 3, got %+v", points)
+	}
+	if assignee := q.Terms[2]; !assignee.Negate || assignee.Values[0] != "dev-2" {
+		t.Errorf("Expected negated assignee dev-2, got %+v", assignee)
+	}
+	if phrase := q.Terms[3]; phrase.Field != "" || phrase.Values[0] != "two words" {
+		t.Errorf("Expected a quoted search phrase, got %+v", phrase)
+	}
+	if q.Sort[0].Field != "value" || !q.Sort[0].Descending {
+		t.Errorf("Expected descending sort by value, got %+v", q.Sort[0])
+	}
+
+	// Check that the query renders back to an equivalent form
+	again, err := Parse(q.String())
+	if err != nil || again.String() != q.String() {
+		t.Errorf("Expected round trip of %q, got %q (%v)", q.String(), again.String(), err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{`title:"open`, `sort:`, `-sort:value`, `sort:a,b`} {
+		if _, err := Parse(input); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Expected ErrSyntax for %q, got %v", input, err)
+		}
+	}
+}
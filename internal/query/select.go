@@ -0,0 +1,101 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+// ErrUnsupportedKind is returned when querying a kind of entity other than stories, tasks and sprints
+var ErrUnsupportedKind = queryError("entities of this kind cannot be queried")
+
+// Select parses the input and runs it over the entities of one kind in the snapshot, ordered by
+// creation, or by start date for sprints, unless the query sorts them. The result is a slice
+// of stories, tasks or sprints.
+func Select(s *store.Snapshot, kind models.EntityKind, input string) (any, error) {
+	q, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case models.KindStory:
+		stories := values(s.Stories)
+		sort.SliceStable(stories, func(i, j int) bool {
+			return before(stories[i].CreatedAt, stories[i].ID, stories[j].CreatedAt, stories[j].ID)
+		})
+		return q.Stories(stories)
+	case models.KindTask:
+		tasks := values(s.Tasks)
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return before(tasks[i].CreatedAt, tasks[i].ID, tasks[j].CreatedAt, tasks[j].ID)
+		})
+		return q.Tasks(tasks)
+	case models.KindSprint:
+		sprints := values(s.Sprints)
+		sort.SliceStable(sprints, func(i, j int) bool {
+			return before(sprints[i].StartDate, sprints[i].ID, sprints[j].StartDate, sprints[j].ID)
+		})
+		return q.Sprints(sprints)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedKind, kind)
+}
+
+// values returns the entities of a snapshot map
+func values[T any](m map[string]T) []T {
+	out := make([]T, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
+
+// before orders entities by date, then by ID
+func before(a time.Time, aID string, b time.Time, bID string) bool {
+	if !a.Equal(b) {
+		return a.Before(b)
+	}
+	return aID < bID
+}
+
+// Handler serves queries over HTTP: GET with the query in "q" and the entity kind in "kind",
+// story by default, answers with the matching entities as a JSON array. Invalid queries
+// are answered with 400 Bad Request and the error.
+func Handler(state func() *store.Snapshot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		kind := models.EntityKind(r.URL.Query().Get("kind"))
+		if kind == "" {
+			kind = models.KindStory
+		}
+		result, err := Select(state(), kind, r.URL.Query().Get("q"))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if isQueryError(err) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+}
+
+// isQueryError reports whether the error comes from the query rather than the server
+func isQueryError(err error) bool {
+	var qe queryError
+	return errors.As(err, &qe)
+}
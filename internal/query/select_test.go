@@ -0,0 +1,80 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+// newSnapshot records two stories and a task testing the second one
+func newSnapshot() *store.Snapshot {
+	events := store.New()
+	login := models.NewUserStory("Sign in", "")
+	login.Track(events)
+	login.SetEstimate(5)
+	reset := models.NewUserStory("Reset password", "")
+	reset.Track(events)
+	task := models.NewDevTask(reset.ID, "Test the reset mail", "dev-2")
+	task.Type = models.TaskTesting
+	task.Track(events)
+	return events.State()
+}
+
+func TestSelect(t *testing.T) {
+	s := newSnapshot()
+
+	// Check that stories come back in creation order unless sorted
+	result, err := Select(s, models.KindStory, "")
+	if err != nil {
+		t.Fatalf("Failed to select: %v", err)
+	}
+	if stories := result.([]*models.UserStory); len(stories) != 2 || stories[0].Title != "Sign in" {
+		t.Errorf("Expected both stories in creation order, got %v", stories)
+	}
+	result, err = Select(s, models.KindStory, "points:0")
+	if err != nil {
+		t.Fatalf("Failed to select: %v", err)
+	}
+	if stories := result.([]*models.UserStory); len(stories) != 1 || stories[0].Title != "Reset password" {
+		t.Errorf("Expected the unestimated story, got %v", stories)
+	}
+
+	// Check that other kinds are rejected
+	if _, err := Select(s, models.KindImpediment, ""); !errors.Is(err, ErrUnsupportedKind) {
+		t.Errorf("Expected error %v, got %v", ErrUnsupportedKind, err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(Handler(newSnapshot))
+	defer server.Close()
+
+	// Check that matching tasks are answered as JSON
+	resp, err := http.Get(server.URL + "?kind=task&q=type:Testing+assignee:dev-2")
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	defer resp.Body.Close()
+	var tasks []*models.DevTask
+	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || len(tasks) != 1 || tasks[0].Title != "Test the reset mail" {
+		t.Errorf("Expected the testing task, got %d %+v", resp.StatusCode, tasks)
+	}
+
+	// Check that invalid queries are bad requests
+	resp, err = http.Get(server.URL + "?q=colour:red")
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,440 @@
+// Package report builds the Scrum Master's sprint health report in the layout defined by
+// the SM agent prompt, from sprint, story, task, impediment and retrospective data.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// reportError implements the error interface
+type reportError string
+
+func (e reportError) Error() string {
+	return string(e)
+}
+
+// ErrNoSprint is returned when building a report without a sprint
+var ErrNoSprint = reportError("no sprint to report on")
+
+// ErrInvalidThreshold is returned when a threshold's yellow bound is past its green bound
+var ErrInvalidThreshold = reportError("yellow bound must not be past the green bound")
+
+// TimeLayout is the format of the report time
+const TimeLayout = "2006-01-02 15:04"
+
+// Color is the health of a metric or of the whole sprint
+type Color string
+
+const (
+	Green  Color = "Green"
+	Yellow Color = "Yellow"
+	Red    Color = "Red"
+)
+
+// colorLabels are the colors as written in the SM prompt
+var colorLabels = map[Color]string{Green: "绿色", Yellow: "黄色", Red: "红色"}
+
+// worse returns the worse of two colors
+func worse(a, b Color) Color {
+	rank := map[Color]int{Green: 0, Yellow: 1, Red: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// Trend is the direction of the average cycle time compared to earlier sprints
+type Trend string
+
+const (
+	TrendRising  Trend = "Rising"
+	TrendFalling Trend = "Falling"
+	TrendStable  Trend = "Stable"
+)
+
+// trendLabels are the trends as written in the SM prompt
+var trendLabels = map[Trend]string{TrendRising: "上升", TrendFalling: "下降", TrendStable: "平稳"}
+
+// Threshold maps a metric's value to a color. Values at or past Green are green, at or
+// past Yellow yellow and red otherwise, where past means above unless LowerIsBetter.
+type Threshold struct {
+	Green         float64 `json:"green"`
+	Yellow        float64 `json:"yellow"`
+	LowerIsBetter bool    `json:"lower_is_better,omitempty"`
+}
+
+// Color returns the color of the value
+func (t Threshold) Color(value float64) Color {
+	if t.LowerIsBetter {
+		value, t.Green, t.Yellow = -value, -t.Green, -t.Yellow
+	}
+	switch {
+	case value >= t.Green:
+		return Green
+	case value >= t.Yellow:
+		return Yellow
+	}
+	return Red
+}
+
+// Valid reports whether the yellow bound is not past the green bound
+func (t Threshold) Valid() bool {
+	if t.LowerIsBetter {
+		return t.Yellow >= t.Green
+	}
+	return t.Yellow <= t.Green
+}
+
+// Thresholds configure how metrics turn into the sprint's health color
+type Thresholds struct {
+	Achievement    Threshold `json:"achievement"`     // Share of planned points delivered
+	Completion     Threshold `json:"completion"`      // Share of committed stories finished
+	ImpedimentAge  Threshold `json:"impediment_age"`  // Days the oldest unresolved impediment has waited
+	Process        Threshold `json:"process"`         // Process adherence score out of 5
+	Improvement    Threshold `json:"improvement"`     // Share of planned action items done
+	TrendTolerance float64   `json:"trend_tolerance"` // Relative cycle time change still counted as stable
+}
+
+// DefaultThresholds returns the thresholds used when none are configured
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Achievement:    Threshold{Green: 0.9, Yellow: 0.7},
+		Completion:     Threshold{Green: 0.8, Yellow: 0.6},
+		ImpedimentAge:  Threshold{Green: 2, Yellow: 5, LowerIsBetter: true},
+		Process:        Threshold{Green: 4, Yellow: 3},
+		Improvement:    Threshold{Green: 0.7, Yellow: 0.4},
+		TrendTolerance: 0.1,
+	}
+}
+
+// Validate checks that every threshold is ordered
+func (t Thresholds) Validate() error {
+	named := []struct {
+		name string
+		t    Threshold
+	}{
+		{"achievement", t.Achievement},
+		{"completion", t.Completion},
+		{"impediment_age", t.ImpedimentAge},
+		{"process", t.Process},
+		{"improvement", t.Improvement},
+	}
+	for _, n := range named {
+		if !n.t.Valid() {
+			return fmt.Errorf("%w: %s", ErrInvalidThreshold, n.name)
+		}
+	}
+	return nil
+}
+
+// Input is the data a health report is built from
+type Input struct {
+	Sprint         *models.Sprint
+	Previous       []*models.Sprint // Earlier sprints, for the cycle time trend
+	Stories        []*models.UserStory
+	Tasks          []*models.DevTask
+	Impediments    []*models.Impediment
+	Retrospectives []*models.Retrospective // Retrospectives whose action items were planned for the sprint
+	ProcessScore   float64                 // Scrum event adherence out of 5, 0 when not rated
+}
+
+// Velocity compares the planned and delivered story points
+type Velocity struct {
+	Planned     int     `json:"planned"` // Points committed to the sprint
+	Actual      int     `json:"actual"`  // Points of completed stories
+	Achievement float64 `json:"achievement"`
+}
+
+// Completion counts the committed stories that were finished
+type Completion struct {
+	Done  int     `json:"done"`
+	Total int     `json:"total"`
+	Rate  float64 `json:"rate"`
+}
+
+// CycleTime is the average cycle time of the sprint's finished tasks
+type CycleTime struct {
+	AverageDays  float64 `json:"average_days"`
+	PreviousDays float64 `json:"previous_days"` // Average over the previous sprints, 0 without data
+	Tasks        int     `json:"tasks"`         // Finished tasks averaged over
+	Trend        Trend   `json:"trend"`
+}
+
+// Impediments tracks the impediments open during the sprint
+type Impediments struct {
+	New                int     `json:"new"`         // Reported since the sprint started
+	Unresolved         int     `json:"unresolved"`  // Open or being resolved
+	InProgress         int     `json:"in_progress"` // Being resolved
+	Resolved           int     `json:"resolved"`
+	AverageResolveDays float64 `json:"average_resolve_days"`
+	LongestOpenDays    float64 `json:"longest_open_days"`
+}
+
+// TeamStatus is the overall health of the team
+type TeamStatus struct {
+	Health              Color   `json:"health"`
+	ProcessScore        float64 `json:"process_score"` // Out of 5, 0 when not rated
+	ImprovementsDone    int     `json:"improvements_done"`
+	ImprovementsPlanned int     `json:"improvements_planned"`
+}
+
+// Signal is the color of one metric, explaining the overall health
+type Signal struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Color  Color   `json:"color"`
+}
+
+// HealthReport is the SM agent's sprint health report
+type HealthReport struct {
+	SprintID    string      `json:"sprint_id"`
+	Goal        string      `json:"goal"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	Velocity    Velocity    `json:"velocity"`
+	Stories     Completion  `json:"stories"`
+	CycleTime   CycleTime   `json:"cycle_time"`
+	Impediments Impediments `json:"impediments"`
+	Team        TeamStatus  `json:"team"`
+	Signals     []Signal    `json:"signals"` // Metrics the health color was derived from
+}
+
+// Health builds the sprint's health report at the given time. Only metrics with data
+// count towards the health color; a rising cycle time makes it yellow at best.
+func Health(in Input, thresholds Thresholds, now time.Time) (*HealthReport, error) {
+	if in.Sprint == nil {
+		return nil, ErrNoSprint
+	}
+	if err := thresholds.Validate(); err != nil {
+		return nil, err
+	}
+
+	sprint := in.Sprint
+	points := models.StoryPoints(in.Stories...)
+	report := &HealthReport{SprintID: sprint.ID, Goal: sprint.Goal, GeneratedAt: now, Signals: []Signal{}}
+	signal := func(metric string, value float64, t Threshold) {
+		report.Signals = append(report.Signals, Signal{Metric: metric, Value: value, Color: t.Color(value)})
+	}
+
+	// Velocity and story completion
+	for _, id := range sprint.Committed {
+		report.Velocity.Planned += points(id)
+	}
+	report.Velocity.Actual = sprint.CompletedPoints(points)
+	report.Velocity.Achievement = ratio(report.Velocity.Actual, report.Velocity.Planned)
+	if report.Velocity.Planned > 0 {
+		signal("achievement", report.Velocity.Achievement, thresholds.Achievement)
+	}
+	report.Stories = Completion{Done: len(sprint.Completed), Total: len(sprint.Committed)}
+	report.Stories.Rate = ratio(report.Stories.Done, report.Stories.Total)
+	if report.Stories.Total > 0 {
+		signal("completion", report.Stories.Rate, thresholds.Completion)
+	}
+
+	// Cycle time against the previous sprints
+	current, n := averageCycleDays(sprint.Committed, in.Tasks)
+	var earlier []string
+	for _, s := range in.Previous {
+		earlier = append(earlier, s.Committed...)
+	}
+	previous, _ := averageCycleDays(earlier, in.Tasks)
+	report.CycleTime = CycleTime{AverageDays: current, PreviousDays: previous, Tasks: n, Trend: TrendStable}
+	if n > 0 && previous > 0 {
+		switch change := (current - previous) / previous; {
+		case change > thresholds.TrendTolerance:
+			report.CycleTime.Trend = TrendRising
+		case change < -thresholds.TrendTolerance:
+			report.CycleTime.Trend = TrendFalling
+		}
+	}
+
+	// Impediments open at some point during the sprint, as they stood at its end once it is over
+	end := now
+	if !sprint.EndDate.IsZero() && sprint.EndDate.Before(now) {
+		end = sprint.EndDate
+	}
+	var relevant []*models.Impediment
+	for _, im := range in.Impediments {
+		if im.ReportedAt.After(end) || (im.ResolvedAt != nil && im.ResolvedAt.Before(sprint.StartDate)) {
+			continue
+		}
+		relevant = append(relevant, asOf(im, end))
+		if !im.ReportedAt.Before(sprint.StartDate) {
+			report.Impediments.New++
+		}
+	}
+	stats := models.SummarizeImpediments(relevant, end)
+	report.Impediments.Unresolved = stats.Open + stats.InProgress
+	report.Impediments.InProgress = stats.InProgress
+	report.Impediments.Resolved = stats.Resolved
+	report.Impediments.AverageResolveDays = days(stats.AverageResolve)
+	report.Impediments.LongestOpenDays = days(stats.LongestOpen)
+	signal("impediment_age", report.Impediments.LongestOpenDays, thresholds.ImpedimentAge)
+
+	// Team status
+	report.Team.ProcessScore = in.ProcessScore
+	if in.ProcessScore > 0 {
+		signal("process", in.ProcessScore, thresholds.Process)
+	}
+	seen := make(map[string]bool)
+	for _, r := range in.Retrospectives {
+		for _, item := range r.ActionItems {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			report.Team.ImprovementsPlanned++
+			if item.Status == models.ActionDone {
+				report.Team.ImprovementsDone++
+			}
+		}
+	}
+	if report.Team.ImprovementsPlanned > 0 {
+		signal("improvement", ratio(report.Team.ImprovementsDone, report.Team.ImprovementsPlanned), thresholds.Improvement)
+	}
+
+	report.Team.Health = Green
+	for _, s := range report.Signals {
+		report.Team.Health = worse(report.Team.Health, s.Color)
+	}
+	if report.CycleTime.Trend == TrendRising {
+		report.Signals = append(report.Signals, Signal{Metric: "cycle_time", Value: current, Color: Yellow})
+		report.Team.Health = worse(report.Team.Health, Yellow)
+	}
+	return report, nil
+}
+
+// averageCycleDays averages the cycle time of the finished tasks of the stories, in days
+func averageCycleDays(storyIDs []string, tasks []*models.DevTask) (float64, int) {
+	stories := make(map[string]bool, len(storyIDs))
+	for _, id := range storyIDs {
+		stories[id] = true
+	}
+	var total time.Duration
+	n := 0
+	for _, t := range tasks {
+		if !stories[t.StoryID] {
+			continue
+		}
+		if d, ok := t.CycleTime(); ok {
+			total += d
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return days(total / time.Duration(n)), n
+}
+
+// ratio returns part over whole, or 0 when whole is 0
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+// days converts a duration to days rounded to a tenth
+func days(d time.Duration) float64 {
+	return math.Round(d.Hours()/24*10) / 10
+}
+
+// percent formats a ratio as a whole percentage
+func percent(r float64) string {
+	return fmt.Sprintf("%.0f%%", r*100)
+}
+
+// score formats the process score out of 5, or "-" when not rated
+func (r *HealthReport) score() string {
+	if r.Team.ProcessScore == 0 {
+		return "-/5"
+	}
+	return fmt.Sprintf("%.1f/5", r.Team.ProcessScore)
+}
+
+// lines returns the report's sections and their lines in the SM prompt's layout
+func (r *HealthReport) lines() [][2]string {
+	return [][2]string{
+		{"效能指标", fmt.Sprintf("计划速率: %d | 实际速率: %d | 达成率: %s",
+			r.Velocity.Planned, r.Velocity.Actual, percent(r.Velocity.Achievement))},
+		{"", fmt.Sprintf("故事完成数: %d/%d | 完成率: %s", r.Stories.Done, r.Stories.Total, percent(r.Stories.Rate))},
+		{"", fmt.Sprintf("平均周期时间: %.1f天 | 趋势: %s", r.CycleTime.AverageDays, trendLabels[r.CycleTime.Trend])},
+		{"障碍跟踪", fmt.Sprintf("新增障碍: %d | 解决中: %d | 已解决: %d",
+			r.Impediments.New, r.Impediments.InProgress, r.Impediments.Resolved)},
+		{"", fmt.Sprintf("平均解决时间: %.1f天 | 最长待解决: %.1f天",
+			r.Impediments.AverageResolveDays, r.Impediments.LongestOpenDays)},
+		{"团队状态", "协作健康度: " + colorLabels[r.Team.Health]},
+		{"", "流程遵循度: " + r.score()},
+		{"", fmt.Sprintf("改进实施: %d/%d", r.Team.ImprovementsDone, r.Team.ImprovementsPlanned)},
+	}
+}
+
+// Text renders the report as plain text, exactly in the SM prompt's layout
+func (r *HealthReport) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "迭代: %s - %s\n", r.SprintID, r.Goal)
+	fmt.Fprintf(&b, "报告时间: %s\n", r.GeneratedAt.Format(TimeLayout))
+	for _, line := range r.lines() {
+		if line[0] != "" {
+			fmt.Fprintf(&b, "\n%s:\n", line[0])
+		}
+		fmt.Fprintf(&b, "- %s\n", line[1])
+	}
+	return b.String()
+}
+
+// Markdown renders the report as Markdown, listing the metrics that are not green
+func (r *HealthReport) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# 迭代健康度报告\n\n")
+	fmt.Fprintf(&b, "**迭代:** %s - %s\n\n", r.SprintID, r.Goal)
+	fmt.Fprintf(&b, "**报告时间:** %s\n", r.GeneratedAt.Format(TimeLayout))
+	for _, line := range r.lines() {
+		if line[0] != "" {
+			fmt.Fprintf(&b, "\n## %s\n\n", line[0])
+		}
+		fmt.Fprintf(&b, "- %s\n", line[1])
+	}
+
+	var warnings []Signal
+	for _, s := range r.Signals {
+		if s.Color != Green {
+			warnings = append(warnings, s)
+		}
+	}
+	if len(warnings) > 0 {
+		b.WriteString("\n## 关注项\n\n")
+		for _, s := range warnings {
+			fmt.Fprintf(&b, "- %s: %s (%s)\n", s.Metric, formatValue(s.Value), colorLabels[s.Color])
+		}
+	}
+	return b.String()
+}
+
+// formatValue formats a signal value with at most two decimals
+func formatValue(v float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
+
+// JSON renders the report as indented JSON
+func (r *HealthReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// asOf returns the impediment as it stood at the given time; one resolved later was still
+// being resolved then
+func asOf(im *models.Impediment, t time.Time) *models.Impediment {
+	if im.ResolvedAt == nil || !im.ResolvedAt.After(t) {
+		return im
+	}
+	past := *im
+	past.Status = models.ImpedimentInProgress
+	past.ResolvedAt = nil
+	return &past
+}
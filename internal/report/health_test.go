@@ -0,0 +1,200 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"egodteam/internal/data/models"
+)
+
+// day0 is the start of the reported sprint
+var day0 = time.Date(2025, 9, 22, 9, 0, 0, 0, time.UTC)
+
+// at returns the time the given number of days after day0
+func at(days float64) *time.Time {
+	t := day0.Add(time.Duration(days * 24 * float64(time.Hour)))
+	return &t
+}
+
+// story returns a story with an estimate
+func story(id string, points int) *models.UserStory {
+	return &models.UserStory{ID: id, Estimate: &models.StoryEstimate{Points: points}}
+}
+
+// task returns a task of the story that took the given days from start to finish
+func task(storyID string, start, end float64) *models.DevTask {
+	return &models.DevTask{StoryID: storyID, Status: models.TaskDone, StartedAt: at(start), CompletedAt: at(end)}
+}
+
+// healthInput is a sprint that delivered 5 of 8 points with a slower cycle time than before
+func healthInput() Input {
+	sprint := &models.Sprint{ID: "sprint-2", Goal: "Checkout", StartDate: day0, EndDate: *at(10),
+		Committed: []string{"a", "b"}, Completed: []string{"a"}}
+	previous := &models.Sprint{ID: "sprint-1", Committed: []string{"p"}, Completed: []string{"p"}}
+	retro := &models.Retrospective{ActionItems: []*models.ActionItem{
+		{ID: "action-1", Status: models.ActionDone},
+		{ID: "action-2", Status: models.ActionOpen},
+	}}
+	return Input{
+		Sprint:   sprint,
+		Previous: []*models.Sprint{previous},
+		Stories:  []*models.UserStory{story("a", 5), story("b", 3), story("p", 2)},
+		Tasks:    []*models.DevTask{task("a", 0, 2), task("a", 1, 2), task("p", -5, -4), {StoryID: "b", Status: models.TaskInProgress}},
+		Impediments: []*models.Impediment{
+			{ID: "im-1", Status: models.ImpedimentResolved, ReportedAt: *at(1), ResolvedAt: at(2)},
+			{ID: "im-2", Status: models.ImpedimentInProgress, ReportedAt: *at(2)},
+			{ID: "im-3", Status: models.ImpedimentResolved, ReportedAt: *at(-3), ResolvedAt: at(-1)},
+		},
+		Retrospectives: []*models.Retrospective{retro, retro},
+		ProcessScore:   4.5,
+	}
+}
+
+func TestHealth(t *testing.T) {
+	report, err := Health(healthInput(), DefaultThresholds(), *at(4))
+	if err != nil {
+		t.Fatalf("Failed to build report: %v", err)
+	}
+
+	// Check velocity and completion
+	if report.Velocity != (Velocity{Planned: 8, Actual: 5, Achievement: 0.625}) {
+		t.Errorf("Expected 5 of 8 points, got %+v", report.Velocity)
+	}
+	if report.Stories != (Completion{Done: 1, Total: 2, Rate: 0.5}) {
+		t.Errorf("Expected 1 of 2 stories, got %+v", report.Stories)
+	}
+
+	// Check that a cycle time of 1.5 days against 1 day before is rising
+	if report.CycleTime != (CycleTime{AverageDays: 1.5, PreviousDays: 1, Tasks: 2, Trend: TrendRising}) {
+		t.Errorf("Expected a rising 1.5 day cycle time, got %+v", report.CycleTime)
+	}
+
+	// Check that impediments resolved before the sprint are left out
+	want := Impediments{New: 2, Unresolved: 1, InProgress: 1, Resolved: 1, AverageResolveDays: 1, LongestOpenDays: 2}
+	if report.Impediments != want {
+		t.Errorf("Expected %+v, got %+v", want, report.Impediments)
+	}
+
+	// Check that shared action items count once and the low delivery makes the sprint red
+	if report.Team != (TeamStatus{Health: Red, ProcessScore: 4.5, ImprovementsDone: 1, ImprovementsPlanned: 2}) {
+		t.Errorf("Expected a red team with 1 of 2 improvements, got %+v", report.Team)
+	}
+	colors := map[string]Color{}
+	for _, s := range report.Signals {
+		colors[s.Metric] = s.Color
+	}
+	if colors["achievement"] != Red || colors["impediment_age"] != Green || colors["improvement"] != Yellow || colors["cycle_time"] != Yellow {
+		t.Errorf("Expected red achievement, green impediments, yellow improvement and cycle time, got %v", colors)
+	}
+}
+
+func TestHealthAfterSprintEnd(t *testing.T) {
+	in := healthInput()
+	in.Impediments = append(in.Impediments,
+		&models.Impediment{ID: "im-4", Status: models.ImpedimentOpen, ReportedAt: *at(12)},
+		&models.Impediment{ID: "im-5", Status: models.ImpedimentResolved, ReportedAt: *at(5), ResolvedAt: at(15)},
+	)
+	report, err := Health(in, DefaultThresholds(), *at(20))
+	if err != nil {
+		t.Fatalf("Failed to build report: %v", err)
+	}
+
+	// Check that impediments reported after the sprint ended are left out and one resolved
+	// after it ended was still being resolved
+	if report.Impediments.New != 3 || report.Impediments.Unresolved != 2 || report.Impediments.InProgress != 2 || report.Impediments.Resolved != 1 {
+		t.Errorf("Expected 3 new, 2 being resolved and 1 resolved impediment, got %+v", report.Impediments)
+	}
+
+	// Check that the age of open impediments stops at the sprint end, 8 days after im-2 was reported
+	if report.Impediments.LongestOpenDays != 8 {
+		t.Errorf("Expected the longest open impediment capped at 8 days, got %v", report.Impediments.LongestOpenDays)
+	}
+}
+
+func TestHealthThresholds(t *testing.T) {
+	thresholds := DefaultThresholds()
+	thresholds.Achievement = Threshold{Green: 0.6, Yellow: 0.5}
+	thresholds.Completion = Threshold{Green: 0.5, Yellow: 0.4}
+	thresholds.Improvement = Threshold{Green: 0.5, Yellow: 0.2}
+	thresholds.TrendTolerance = 0.6
+
+	// Check that looser thresholds turn the same sprint green
+	report, err := Health(healthInput(), thresholds, *at(4))
+	if err != nil {
+		t.Fatalf("Failed to build report: %v", err)
+	}
+	if report.Team.Health != Green || report.CycleTime.Trend != TrendStable {
+		t.Errorf("Expected a green sprint with a stable cycle time, got %+v", report)
+	}
+
+	// Check lower-is-better thresholds
+	age := Threshold{Green: 2, Yellow: 5, LowerIsBetter: true}
+	if age.Color(1) != Green || age.Color(5) != Yellow || age.Color(6) != Red {
+		t.Errorf("Expected green, yellow and red impediment ages")
+	}
+
+	// Check that unordered thresholds and a missing sprint are rejected
+	thresholds.ImpedimentAge = Threshold{Green: 5, Yellow: 2, LowerIsBetter: true}
+	if _, err := Health(healthInput(), thresholds, *at(4)); !errors.Is(err, ErrInvalidThreshold) {
+		t.Errorf("Expected ErrInvalidThreshold, got %v", err)
+	}
+	if _, err := Health(Input{}, DefaultThresholds(), *at(4)); err != ErrNoSprint {
+		t.Errorf("Expected ErrNoSprint, got %v", err)
+	}
+}
+
+func TestHealthRendering(t *testing.T) {
+	report, err := Health(healthInput(), DefaultThresholds(), *at(4))
+	if err != nil {
+		t.Fatalf("Failed to build report: %v", err)
+	}
+
+	// Check the plain text follows the SM prompt's layout
+	want := `迭代: sprint-2 - Checkout
+报告时间: 2025-09-26 09:00
+
+效能指标:
+- 计划速率: 8 | 实际速率: 5 | 达成率: 62%
+- 故事完成数: 1/2 | 完成率: 50%
+- 平均周期时间: 1.5天 | 趋势: 上升
+
+障碍跟踪:
+- 新增障碍: 2 | 解决中: 1 | 已解决: 1
+- 平均解决时间: 1.0天 | 最长待解决: 2.0天
+
+团队状态:
+- 协作健康度: 红色
+- 流程遵循度: 4.5/5
+- 改进实施: 1/2
+`
+	if text := report.Text(); text != want {
+		t.Errorf("Expected text:\n%s\ngot:\n%s", want, text)
+	}
+
+	// Check the Markdown sections and the metrics needing attention
+	markdown := report.Markdown()
+	for _, part := range []string{"# 迭代健康度报告", "## 障碍跟踪", "- 改进实施: 1/2", "## 关注项", "- achievement: 0.62 (红色)"} {
+		if !strings.Contains(markdown, part) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", part, markdown)
+		}
+	}
+	if strings.Contains(markdown, "impediment_age") {
+		t.Errorf("Expected green metrics to be left out of the attention list")
+	}
+
+	// Check the JSON round trip
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("Failed to render JSON: %v", err)
+	}
+	var decoded HealthReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if decoded.Team.Health != Red || decoded.Velocity.Planned != 8 || len(decoded.Signals) != len(report.Signals) {
+		t.Errorf("Expected the decoded report to match, got %+v", decoded)
+	}
+}
@@ -0,0 +1,388 @@
+package scrum
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+)
+
+// ErrUnknownEstimator is returned when a vote comes from someone not invited to the session
+var ErrUnknownEstimator = scrumError("estimator is not part of the session")
+
+// ErrOffScale is returned when a vote is not on the Fibonacci scale
+var ErrOffScale = scrumError("points are not on the Fibonacci scale")
+
+// ErrInvalidHours is returned when a three-point estimate is not optimistic <= likely <= pessimistic
+var ErrInvalidHours = scrumError("invalid three-point estimate")
+
+// ErrEstimationClosed is returned when voting in a session that reached its result
+var ErrEstimationClosed = scrumError("estimation session is closed")
+
+// ErrNoVotes is returned when revealing a round nobody voted in
+var ErrNoVotes = scrumError("no votes in the round")
+
+// DefaultMaxRounds is how many rounds a session runs before settling without consensus
+const DefaultMaxRounds = 3
+
+// Estimation runs planning poker on one story over the agent bus. Each round the Dev agents
+// vote Fibonacci points with an optional three-point estimate in hours. Votes at most one
+// step apart on the scale are a consensus; otherwise the lowest and highest voters are asked
+// to justify before the next round. The result is stored on the story with its history.
+type Estimation struct {
+	Story     *models.UserStory
+	MaxRounds int              // Defaults to DefaultMaxRounds
+	Publisher Publisher        // Receives the session's messages, may be nil
+	Now       func() time.Time // Defaults to time.Now
+
+	mutex          sync.Mutex
+	session        *models.EstimationSession
+	votes          map[string]models.EstimationVote // Current round
+	justifications map[string]string                // Outliers of the last revealed round
+	outliers       []string                         // Waiting for justifications when not empty
+	done           bool
+}
+
+// NewEstimation creates a session on the story for the estimators
+func NewEstimation(us *models.UserStory, pub Publisher, estimators ...string) *Estimation {
+	return &Estimation{
+		Story:     us,
+		MaxRounds: DefaultMaxRounds,
+		Publisher: pub,
+		Now:       time.Now,
+		session:   models.NewEstimationSession(us.ID, estimators...),
+		votes:     make(map[string]models.EstimationVote),
+	}
+}
+
+// now returns the current time from the configured clock
+func (e *Estimation) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+// Session returns a copy of the session history so far
+func (e *Estimation) Session() models.EstimationSession {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	session := *e.session
+	session.Rounds = append([]models.EstimationRound(nil), e.session.Rounds...)
+	return session
+}
+
+// Done reports whether the session reached its result
+func (e *Estimation) Done() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.done
+}
+
+// Start opens the first round
+func (e *Estimation) Start() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.session.StartedAt = e.now()
+	return e.requestRound()
+}
+
+// round returns the number of the round being voted or justified
+func (e *Estimation) round() int {
+	if len(e.outliers) > 0 {
+		return len(e.session.Rounds)
+	}
+	return len(e.session.Rounds) + 1
+}
+
+// requestRound asks every estimator to vote in the next round
+func (e *Estimation) requestRound() error {
+	return e.publish(communication.DevAgent, communication.MsgEstimateRequest, communication.EstimateRequestPayload{
+		SessionID:      e.session.ID,
+		StoryID:        e.Story.ID,
+		Title:          e.Story.Title,
+		Description:    e.Story.Description,
+		Criteria:       e.Story.AcceptanceCriteria,
+		Round:          e.round(),
+		Scale:          models.FibonacciScale,
+		Estimators:     e.session.Estimators,
+		Justifications: e.justifications,
+	})
+}
+
+// publish sends a message from the Scrum Master, if a publisher is set
+func (e *Estimation) publish(to communication.AgentType, messageType communication.MessageType, payload any) error {
+	if e.Publisher == nil {
+		return nil
+	}
+	message, err := communication.NewMessage(communication.SMAgent, to, messageType, communication.MediumPriority, payload)
+	if err != nil {
+		return err
+	}
+	message.Correlation = e.session.ID
+	return e.Publisher.Publish(message)
+}
+
+// HandleMessage records MsgEstimateVote messages for this session's current round; it can be
+// subscribed on the agent bus
+func (e *Estimation) HandleMessage(message *communication.AgentMessage) {
+	if message.Type != communication.MsgEstimateVote {
+		return
+	}
+	var payload communication.EstimateVotePayload
+	if err := message.DecodePayload(&payload); err != nil || payload.SessionID != e.session.ID {
+		return
+	}
+
+	e.mutex.Lock()
+	stale := payload.Round != e.round()
+	e.mutex.Unlock()
+	if stale {
+		return
+	}
+
+	vote := models.EstimationVote{
+		EstimatorID:   payload.EstimatorID,
+		Points:        payload.Points,
+		Justification: payload.Justification,
+	}
+	hours := models.ThreePointEstimate{Optimistic: payload.Optimistic, Likely: payload.Likely, Pessimistic: payload.Pessimistic}
+	if !hours.IsZero() {
+		vote.Hours = &hours
+	}
+	e.Vote(vote)
+}
+
+// Vote records a card in the current round, or an outlier's justification while the session
+// waits for them. The round is revealed once every estimator voted, and the next round opens
+// once every outlier justified.
+func (e *Estimation) Vote(vote models.EstimationVote) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.done {
+		return ErrEstimationClosed
+	}
+	if !contains(e.session.Estimators, vote.EstimatorID) {
+		return fmt.Errorf("%w: %s", ErrUnknownEstimator, vote.EstimatorID)
+	}
+
+	if len(e.outliers) > 0 {
+		if !contains(e.outliers, vote.EstimatorID) || vote.Justification == "" {
+			return nil // Only the outliers' reasons are awaited
+		}
+		e.justifications[vote.EstimatorID] = vote.Justification
+		if len(e.justifications) < len(e.outliers) {
+			return nil
+		}
+		return e.nextRound()
+	}
+
+	if models.ScaleIndex(vote.Points) < 0 {
+		return fmt.Errorf("%w: %d", ErrOffScale, vote.Points)
+	}
+	if vote.Hours != nil && !vote.Hours.Valid() {
+		return fmt.Errorf("%w: %+v", ErrInvalidHours, *vote.Hours)
+	}
+	e.votes[vote.EstimatorID] = vote
+	if len(e.votes) < len(e.session.Estimators) {
+		return nil
+	}
+	return e.reveal()
+}
+
+// Reveal closes the current round with the votes cast so far, as when an estimator does not answer
+func (e *Estimation) Reveal() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.done {
+		return ErrEstimationClosed
+	}
+	if len(e.outliers) > 0 {
+		return nil // Already revealed
+	}
+	return e.reveal()
+}
+
+// NextRound opens the next round without waiting for the remaining justifications
+func (e *Estimation) NextRound() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.done {
+		return ErrEstimationClosed
+	}
+	if len(e.outliers) == 0 {
+		return nil // The current round is still open
+	}
+	return e.nextRound()
+}
+
+// nextRound leaves the justification step and asks for new votes
+func (e *Estimation) nextRound() error {
+	e.outliers = nil
+	err := e.requestRound()
+	e.justifications = nil
+	return err
+}
+
+// reveal records the round and either settles the session or asks the outliers to justify
+func (e *Estimation) reveal() error {
+	if len(e.votes) == 0 {
+		return ErrNoVotes
+	}
+
+	round := models.EstimationRound{Number: len(e.session.Rounds) + 1}
+	for _, id := range e.session.Estimators {
+		if vote, ok := e.votes[id]; ok {
+			round.Votes = append(round.Votes, vote)
+		}
+	}
+	e.votes = make(map[string]models.EstimationVote)
+
+	low, high := spread(round.Votes)
+	if models.ScaleIndex(high)-models.ScaleIndex(low) <= 1 {
+		e.session.Rounds = append(e.session.Rounds, round)
+		return e.finish(round, true)
+	}
+	if round.Number >= e.maxRounds() {
+		e.session.Rounds = append(e.session.Rounds, round)
+		return e.finish(round, false)
+	}
+
+	cards := make(map[string]int, len(round.Votes))
+	for _, vote := range round.Votes {
+		cards[vote.EstimatorID] = vote.Points
+		if vote.Points == low || vote.Points == high {
+			round.Outliers = append(round.Outliers, vote.EstimatorID)
+		}
+	}
+	e.session.Rounds = append(e.session.Rounds, round)
+	e.outliers = round.Outliers
+	e.justifications = make(map[string]string)
+
+	return e.publish(communication.DevAgent, communication.MsgEstimateJustify, communication.EstimateJustifyPayload{
+		SessionID: e.session.ID,
+		StoryID:   e.Story.ID,
+		Round:     round.Number,
+		Outliers:  round.Outliers,
+		Votes:     cards,
+	})
+}
+
+// maxRounds returns the configured number of rounds
+func (e *Estimation) maxRounds() int {
+	if e.MaxRounds > 0 {
+		return e.MaxRounds
+	}
+	return DefaultMaxRounds
+}
+
+// finish settles the session on the final round and stores the result on the story.
+// With consensus the most common card wins, ties going to the larger one; without it the
+// median card is used. Confidence is the share of the final votes matching the result,
+// halved when the session ran out of rounds.
+func (e *Estimation) finish(round models.EstimationRound, consensus bool) error {
+	var points int
+	if consensus {
+		points = mostCommon(round.Votes)
+	} else {
+		points = median(round.Votes)
+	}
+
+	agreeing := 0
+	for _, vote := range round.Votes {
+		if vote.Points == points {
+			agreeing++
+		}
+	}
+	confidence := float64(agreeing) / float64(len(round.Votes))
+	if !consensus {
+		confidence /= 2
+	}
+
+	e.session.EndedAt = e.now()
+	e.session.Consensus = consensus
+	e.session.Points = points
+	e.session.Confidence = confidence
+	e.session.Hours = averageHours(round.Votes)
+	e.done = true
+	e.Story.RecordEstimation(*e.session)
+
+	result := communication.EstimateResultPayload{
+		SessionID:  e.session.ID,
+		StoryID:    e.Story.ID,
+		Points:     points,
+		Confidence: confidence,
+		Consensus:  consensus,
+		Rounds:     len(e.session.Rounds),
+	}
+	if e.session.Hours != nil {
+		result.Hours = e.session.Hours.Expected()
+	}
+	return e.publish(communication.AllAgents, communication.MsgEstimateResult, result)
+}
+
+// spread returns the lowest and highest points voted
+func spread(votes []models.EstimationVote) (int, int) {
+	low, high := votes[0].Points, votes[0].Points
+	for _, vote := range votes[1:] {
+		if vote.Points < low {
+			low = vote.Points
+		}
+		if vote.Points > high {
+			high = vote.Points
+		}
+	}
+	return low, high
+}
+
+// mostCommon returns the most voted points, the larger on a tie
+func mostCommon(votes []models.EstimationVote) int {
+	counts := make(map[int]int)
+	best := votes[0].Points
+	for _, vote := range votes {
+		counts[vote.Points]++
+	}
+	for points, n := range counts {
+		if n > counts[best] || (n == counts[best] && points > best) {
+			best = points
+		}
+	}
+	return best
+}
+
+// median returns the median points, the upper one for an even number of votes
+func median(votes []models.EstimationVote) int {
+	points := make([]int, len(votes))
+	for i, vote := range votes {
+		points[i] = vote.Points
+	}
+	sort.Ints(points)
+	return points[len(points)/2]
+}
+
+// averageHours averages the three-point estimates given, or returns nil if none were
+func averageHours(votes []models.EstimationVote) *models.ThreePointEstimate {
+	var sum models.ThreePointEstimate
+	n := 0
+	for _, vote := range votes {
+		if vote.Hours == nil {
+			continue
+		}
+		sum.Optimistic += vote.Hours.Optimistic
+		sum.Likely += vote.Hours.Likely
+		sum.Pessimistic += vote.Hours.Pessimistic
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	return &models.ThreePointEstimate{
+		Optimistic:  sum.Optimistic / float64(n),
+		Likely:      sum.Likely / float64(n),
+		Pessimistic: sum.Pessimistic / float64(n),
+	}
+}
@@ -0,0 +1,127 @@
+package scrum
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+)
+
+// voteMessage builds a MsgEstimateVote message as a Dev agent would send it
+func voteMessage(t *testing.T, payload communication.EstimateVotePayload) *communication.AgentMessage {
+	message, err := communication.NewMessage(communication.DevAgent, communication.SMAgent, communication.MsgEstimateVote, communication.MediumPriority, payload)
+	if err != nil {
+		t.Fatalf("Failed to create vote: %v", err)
+	}
+	return message
+}
+
+func TestEstimationConsensusAfterJustification(t *testing.T) {
+	us := models.NewUserStory("Export report", "")
+	pub := &publisherStub{}
+	e := NewEstimation(us, pub, "dev-1", "dev-2", "dev-3")
+	if err := e.Start(); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	id := e.Session().ID
+
+	// Check that the first round was requested from the Dev agents
+	if len(pub.messages) != 1 || pub.messages[0].Type != communication.MsgEstimateRequest || pub.messages[0].To != communication.DevAgent {
+		t.Fatalf("Expected one estimate request to dev, got %v", pub.messages)
+	}
+
+	// Round 1 spreads from 2 to 13
+	for estimator, points := range map[string]int{"dev-1": 2, "dev-2": 5, "dev-3": 13} {
+		e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 1, EstimatorID: estimator, Points: points}))
+	}
+
+	// Check that the outliers were asked to justify
+	if len(pub.messages) != 2 || pub.messages[1].Type != communication.MsgEstimateJustify {
+		t.Fatalf("Expected a justify request, got %d messages", len(pub.messages))
+	}
+	var justify communication.EstimateJustifyPayload
+	pub.messages[1].DecodePayload(&justify)
+	if len(justify.Outliers) != 2 || justify.Outliers[0] != "dev-1" || justify.Outliers[1] != "dev-3" {
+		t.Errorf("Expected outliers dev-1 and dev-3, got %v", justify.Outliers)
+	}
+
+	// The next round opens once both outliers justified
+	e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 1, EstimatorID: "dev-1", Justification: "Reuses the CSV writer"}))
+	e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 1, EstimatorID: "dev-3", Justification: "PDF layout is new"}))
+	var request communication.EstimateRequestPayload
+	pub.messages[2].DecodePayload(&request)
+	if request.Round != 2 || request.Justifications["dev-3"] != "PDF layout is new" {
+		t.Errorf("Expected round 2 with the justifications, got %+v", request)
+	}
+
+	// Votes for a stale round are ignored
+	e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 1, EstimatorID: "dev-1", Points: 1}))
+
+	// Round 2 converges on 5 and 8
+	hours := []float64{4, 6, 10}
+	e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 2, EstimatorID: "dev-1", Points: 5, Optimistic: hours[0], Likely: hours[1], Pessimistic: hours[2]}))
+	e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 2, EstimatorID: "dev-2", Points: 5}))
+	e.HandleMessage(voteMessage(t, communication.EstimateVotePayload{SessionID: id, Round: 2, EstimatorID: "dev-3", Points: 8, Optimistic: 6, Likely: 8, Pessimistic: 16}))
+
+	// Check the result on the story
+	if !e.Done() {
+		t.Fatalf("Expected the session to be done")
+	}
+	est := us.Estimate
+	if est.Points != 5 || math.Abs(est.Confidence-2.0/3) > 1e-9 {
+		t.Errorf("Expected 5 points at 0.67 confidence, got %d at %v", est.Points, est.Confidence)
+	}
+	if est.Hours == nil || est.Hours.Optimistic != 5 || est.Hours.Likely != 7 || est.Hours.Pessimistic != 13 {
+		t.Errorf("Expected averaged hours 5/7/13, got %+v", est.Hours)
+	}
+	if len(est.History) != 1 || len(est.History[0].Rounds) != 2 || !est.History[0].Consensus {
+		t.Errorf("Expected one session of two rounds with consensus, got %+v", est.History)
+	}
+
+	// Check that the result was broadcast
+	last := pub.messages[len(pub.messages)-1]
+	var result communication.EstimateResultPayload
+	last.DecodePayload(&result)
+	if last.Type != communication.MsgEstimateResult || last.To != communication.AllAgents || result.Points != 5 || result.Rounds != 2 {
+		t.Errorf("Expected a broadcast result of 5 points after 2 rounds, got %s to %s with %+v", last.Type, last.To, result)
+	}
+
+	// Check that the closed session refuses votes
+	if err := e.Vote(models.EstimationVote{EstimatorID: "dev-1", Points: 3}); !errors.Is(err, ErrEstimationClosed) {
+		t.Errorf("Expected ErrEstimationClosed, got %v", err)
+	}
+}
+
+func TestEstimationWithoutConsensus(t *testing.T) {
+	us := models.NewUserStory("Migrate database", "")
+	e := NewEstimation(us, nil, "dev-1", "dev-2", "dev-3")
+	e.MaxRounds = 1
+	e.Start()
+
+	// Check that invalid votes are rejected
+	if err := e.Vote(models.EstimationVote{EstimatorID: "dev-9", Points: 3}); !errors.Is(err, ErrUnknownEstimator) {
+		t.Errorf("Expected ErrUnknownEstimator, got %v", err)
+	}
+	if err := e.Vote(models.EstimationVote{EstimatorID: "dev-1", Points: 4}); !errors.Is(err, ErrOffScale) {
+		t.Errorf("Expected ErrOffScale, got %v", err)
+	}
+	hours := &models.ThreePointEstimate{Optimistic: 8, Likely: 4, Pessimistic: 12}
+	if err := e.Vote(models.EstimationVote{EstimatorID: "dev-1", Points: 3, Hours: hours}); !errors.Is(err, ErrInvalidHours) {
+		t.Errorf("Expected ErrInvalidHours, got %v", err)
+	}
+
+	e.Vote(models.EstimationVote{EstimatorID: "dev-1", Points: 3})
+	e.Vote(models.EstimationVote{EstimatorID: "dev-2", Points: 8})
+
+	// dev-3 does not answer and the round is revealed without them
+	if err := e.Reveal(); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+
+	// Check that the median was taken at half the agreement
+	if us.Estimate.Points != 8 || us.Estimate.Confidence != 0.25 || us.Estimate.History[0].Consensus {
+		t.Errorf("Expected 8 points at 0.25 confidence without consensus, got %d at %v", us.Estimate.Points, us.Estimate.Confidence)
+	}
+}
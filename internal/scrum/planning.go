@@ -0,0 +1,292 @@
+// Package scrum implements the scrum ceremonies run by the SM agent
+// in the agile team intelligent agent system.
+package scrum
+
+import (
+	"fmt"
+	"time"
+
+	"egodteam/internal/backlog"
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+	"egodteam/internal/forecast"
+)
+
+// scrumError implements the error interface
+type scrumError string
+
+func (e scrumError) Error() string {
+	return string(e)
+}
+
+// ErrNoCapacity is returned when neither team capacity nor velocity history bounds the plan
+var ErrNoCapacity = scrumError("no capacity or velocity to plan against")
+
+// ErrSprintNotPlanned is returned when planning or starting a sprint that already started
+var ErrSprintNotPlanned = scrumError("sprint is not in planning")
+
+// ErrPlanMismatch is returned when starting a sprint with the plan of another sprint
+var ErrPlanMismatch = scrumError("plan is for another sprint")
+
+// DefaultConfidence is the share of simulated sprints that must reach the velocity target
+const DefaultConfidence = 0.85
+
+// Publisher sends messages to other agents, as implemented by communication.AgentBus
+type Publisher interface {
+	Publish(message *communication.AgentMessage) error
+}
+
+// Breakdown splits a story into tasks, given the hours one story point represents
+type Breakdown func(us *models.UserStory, hoursPerPoint float64) []*models.DevTask
+
+// Planner fills a sprint from the ranked backlog
+type Planner struct {
+	Team          *models.Team
+	HoursPerPoint float64           // Converts capacity hours to points and points to task estimates
+	History       forecast.History  // Past velocity, bounds the plan when not empty
+	Confidence    float64           // Defaults to DefaultConfidence
+	Seed          int64             // Seed of the velocity forecast, keeps plans reproducible
+	Breakdown     Breakdown         // Defaults to DefaultBreakdown
+	Tasks         []*models.DevTask // Existing tasks, stories that have some are not broken down again
+}
+
+// NewPlanner creates a planner bounded by the team's capacity and its velocity history
+func NewPlanner(team *models.Team, history forecast.History, hoursPerPoint float64) *Planner {
+	return &Planner{
+		Team:          team,
+		HoursPerPoint: hoursPerPoint,
+		History:       history,
+		Confidence:    DefaultConfidence,
+		Breakdown:     DefaultBreakdown,
+	}
+}
+
+// PlannedStory is a story committed to the sprint with the tasks it was split into
+type PlannedStory struct {
+	StoryID string   `json:"story_id"`
+	Title   string   `json:"title"`
+	Points  int      `json:"points"`
+	Rank    int      `json:"rank"`
+	TaskIDs []string `json:"task_ids"`
+}
+
+// Flag reports a story that cannot be planned until it is refined
+type Flag struct {
+	StoryID string `json:"story_id"`
+	Reason  string `json:"reason"`
+}
+
+// Plan is the outcome of sprint planning and the payload of MsgSprintStart
+type Plan struct {
+	SprintID  string                   `json:"sprint_id"`
+	Goal      string                   `json:"goal"`
+	StartDate time.Time                `json:"start_date"`
+	EndDate   time.Time                `json:"end_date"`
+	Capacity  int                      `json:"capacity"` // Points the team has time for, 0 if unknown
+	Velocity  int                      `json:"velocity"` // Points the history supports, 0 if unknown
+	Target    int                      `json:"target"`   // Lower of the known capacity and velocity
+	Points    int                      `json:"points"`   // Points committed
+	Stories   []PlannedStory           `json:"stories"`
+	Tasks     []*models.DevTask        `json:"tasks"` // Tasks broken down by this plan
+	Flagged   []Flag                   `json:"flagged,omitempty"`
+	Deferred  []string                 `json:"deferred,omitempty"` // Ready stories that did not fit
+	Warnings  []models.CapacityWarning `json:"warnings,omitempty"`
+}
+
+// Plan commits ranked Ready stories to the sprint in rank order until the target is reached,
+// skipping stories that do not fit so smaller ones further down can still be taken.
+// Stories without an estimate or acceptance criteria are flagged instead of planned.
+// Planning again is idempotent: stories the sprint already commits count against the target
+// first, and stories that already have tasks keep them instead of being broken down again.
+func (p *Planner) Plan(sprint *models.Sprint, ranked []backlog.Ranked) (*Plan, error) {
+	if sprint.Status != models.SprintPlanned {
+		return nil, ErrSprintNotPlanned
+	}
+
+	plan := &Plan{
+		SprintID:  sprint.ID,
+		Goal:      sprint.Goal,
+		StartDate: sprint.StartDate,
+		EndDate:   sprint.EndDate,
+	}
+	if err := p.target(sprint, plan); err != nil {
+		return nil, err
+	}
+
+	breakdown := p.Breakdown
+	if breakdown == nil {
+		breakdown = DefaultBreakdown
+	}
+
+	// Stories committed by an earlier round count before any new one is taken
+	for _, r := range ranked {
+		if us := r.Story; plannable(us) && contains(sprint.Committed, us.ID) {
+			plan.Points += us.Estimate.Points
+		}
+	}
+
+	existing := make(map[string][]*models.DevTask)
+	for _, task := range p.Tasks {
+		existing[task.StoryID] = append(existing[task.StoryID], task)
+	}
+	var kept []*models.DevTask
+
+	for _, r := range ranked {
+		us := r.Story
+		if us == nil || us.Status == models.StoryDone || us.IsSplit() {
+			continue
+		}
+		if reason := unplannable(us); reason != "" {
+			plan.Flagged = append(plan.Flagged, Flag{StoryID: us.ID, Reason: reason})
+			continue
+		}
+		if us.Status != models.StoryReady {
+			continue
+		}
+
+		points := us.Estimate.Points
+		if !contains(sprint.Committed, us.ID) {
+			if plan.Points+points > plan.Target {
+				plan.Deferred = append(plan.Deferred, us.ID)
+				continue
+			}
+			sprint.AddCommittedStory(us.ID)
+			plan.Points += points
+		}
+
+		planned := PlannedStory{StoryID: us.ID, Title: us.Title, Points: points, Rank: r.Rank}
+		if tasks := existing[us.ID]; len(tasks) > 0 {
+			for _, task := range tasks {
+				planned.TaskIDs = append(planned.TaskIDs, task.ID)
+			}
+			kept = append(kept, tasks...)
+		} else {
+			for _, task := range breakdown(us, p.HoursPerPoint) {
+				planned.TaskIDs = append(planned.TaskIDs, task.ID)
+				plan.Tasks = append(plan.Tasks, task)
+			}
+		}
+		plan.Stories = append(plan.Stories, planned)
+	}
+
+	if p.Team != nil {
+		plan.Warnings = p.Team.CheckCapacity(sprint, append(kept, plan.Tasks...))
+	}
+	return plan, nil
+}
+
+// target sets the plan's capacity, velocity and the resulting point target
+func (p *Planner) target(sprint *models.Sprint, plan *Plan) error {
+	known := false
+	if p.Team != nil && p.HoursPerPoint > 0 {
+		plan.Capacity = p.Team.Capacity(sprint, p.HoursPerPoint).Points
+		plan.Target = plan.Capacity
+		known = true
+	}
+
+	if len(p.History) > 0 {
+		confidence := p.Confidence
+		if confidence == 0 {
+			confidence = DefaultConfidence
+		}
+		velocity, err := forecast.NewForecaster(p.History, p.Seed).Commitment(confidence)
+		if err != nil {
+			return err
+		}
+		plan.Velocity = velocity
+		if !known || velocity < plan.Target {
+			plan.Target = velocity
+		}
+		known = true
+	}
+
+	if !known {
+		return ErrNoCapacity
+	}
+	return nil
+}
+
+// plannable reports whether a story is Ready to be committed to a sprint
+func plannable(us *models.UserStory) bool {
+	return us != nil && us.Status == models.StoryReady && !us.IsSplit() && unplannable(us) == ""
+}
+
+// unplannable explains why a story needs refinement before planning, or returns ""
+func unplannable(us *models.UserStory) string {
+	noEstimate := us.Estimate == nil || us.Estimate.Points <= 0
+	noCriteria := len(us.AcceptanceCriteria) == 0
+	switch {
+	case noEstimate && noCriteria:
+		return "missing estimate and acceptance criteria"
+	case noEstimate:
+		return "missing estimate"
+	case noCriteria:
+		return "missing acceptance criteria"
+	}
+	return ""
+}
+
+// breakdownShares splits a story's effort across development, testing and deployment
+var breakdownShares = []struct {
+	taskType models.TaskType
+	verb     string
+	share    float64
+}{
+	{models.TaskDevelopment, "Implement", 0.6},
+	{models.TaskTesting, "Test", 0.3},
+	{models.TaskDeployment, "Deploy", 0.1},
+}
+
+// DefaultBreakdown splits a story into a chain of development, testing and deployment tasks
+// sharing the story's hours 60/30/10. Without a conversion rate tasks keep the default estimate.
+func DefaultBreakdown(us *models.UserStory, hoursPerPoint float64) []*models.DevTask {
+	tasks := make([]*models.DevTask, 0, len(breakdownShares))
+	for i, s := range breakdownShares {
+		task := models.NewDevTask(us.ID, s.verb+": "+us.Title, "")
+		task.Type = s.taskType
+		if hoursPerPoint > 0 && us.Estimate != nil {
+			hours := float64(us.Estimate.Points) * hoursPerPoint * s.share
+			task.Estimate = time.Duration(hours * float64(time.Hour)).Round(time.Minute)
+		}
+		if i > 0 {
+			task.AddDependency(tasks[i-1].ID)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// Start announces the plan to the development team with MsgSprintStart and activates the sprint.
+// Nothing is announced unless the sprint can be activated, and the sprint stays in planning
+// when the announcement cannot be sent.
+func (plan *Plan) Start(sprint *models.Sprint, pub Publisher) error {
+	if sprint.ID != plan.SprintID {
+		return fmt.Errorf("%w: plan is for sprint %s, not %s", ErrPlanMismatch, plan.SprintID, sprint.ID)
+	}
+	if sprint.Status != models.SprintPlanned {
+		return ErrSprintNotPlanned
+	}
+	if err := sprint.CheckStatus(models.SprintActive); err != nil {
+		return err
+	}
+
+	message, err := communication.NewMessage(communication.SMAgent, communication.DevAgent,
+		communication.MsgSprintStart, communication.HighPriority, plan)
+	if err != nil {
+		return err
+	}
+	if err := pub.Publish(message); err != nil {
+		return err
+	}
+	return sprint.SetStatus(models.SprintActive)
+}
+
+// contains reports whether the slice holds the item
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
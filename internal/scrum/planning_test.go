@@ -0,0 +1,255 @@
+package scrum
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"egodteam/internal/backlog"
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+	"egodteam/internal/forecast"
+)
+
+// publisherStub collects published messages, or fails with err when set
+type publisherStub struct {
+	messages []*communication.AgentMessage
+	err      error
+}
+
+func (p *publisherStub) Publish(message *communication.AgentMessage) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+// newReadyStory creates a Ready story with one criterion and the given points
+func newReadyStory(t *testing.T, id string, points int) *models.UserStory {
+	us := models.NewUserStory("Story "+id, "")
+	us.ID = id
+	us.AddAcceptanceCriterion("It works")
+	us.SetEstimate(points)
+	if err := us.SetStatus(models.StoryReady); err != nil {
+		t.Fatalf("Failed to make %s ready: %v", id, err)
+	}
+	return us
+}
+
+// newTestSprint creates a planned sprint over one working week
+func newTestSprint() *models.Sprint {
+	start := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	return models.NewSprint("Implement user authentication", start, start.AddDate(0, 0, 6))
+}
+
+// newTestTeam creates a team of one developer with 28 focused hours a week
+func newTestTeam() *models.Team {
+	team := models.NewTeam("Core")
+	team.AddMember(models.NewMember("Alice", models.RoleDeveloper))
+	return team
+}
+
+// rankAll ranks the stories in the given order
+func rankAll(stories ...*models.UserStory) []backlog.Ranked {
+	ranked := make([]backlog.Ranked, 0, len(stories))
+	for i, us := range stories {
+		ranked = append(ranked, backlog.Ranked{Story: us, StoryID: us.ID, Rank: i + 1})
+	}
+	return ranked
+}
+
+func TestPlannerPlan(t *testing.T) {
+	a := newReadyStory(t, "a", 5)
+	b := newReadyStory(t, "b", 8)
+	c := newReadyStory(t, "c", 3)
+	draft := models.NewUserStory("Story draft", "")
+	draft.ID = "draft"
+	draft.SetEstimate(3)
+	e := newReadyStory(t, "e", 2)
+
+	sprint := newTestSprint()
+	p := NewPlanner(newTestTeam(), forecast.History{10, 10, 10}, 2)
+	plan, err := p.Plan(sprint, rankAll(a, b, c, draft, e))
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	// 28h at 2h per point is 14 points, but velocity only supports 10
+	if plan.Capacity != 14 || plan.Velocity != 10 || plan.Target != 10 {
+		t.Errorf("Expected capacity 14, velocity 10 and target 10, got %d, %d and %d", plan.Capacity, plan.Velocity, plan.Target)
+	}
+
+	// b does not fit and is skipped so the smaller stories below it are still taken
+	expected := []string{"a", "c", "e"}
+	if len(sprint.Committed) != len(expected) {
+		t.Fatalf("Expected %v to be committed, got %v", expected, sprint.Committed)
+	}
+	for i, id := range expected {
+		if sprint.Committed[i] != id || plan.Stories[i].StoryID != id {
+			t.Errorf("Expected #%d to be %s, got %s", i+1, id, sprint.Committed[i])
+		}
+	}
+	if plan.Points != 10 {
+		t.Errorf("Expected 10 points committed, got %d", plan.Points)
+	}
+	if len(plan.Deferred) != 1 || plan.Deferred[0] != "b" {
+		t.Errorf("Expected b to be deferred, got %v", plan.Deferred)
+	}
+
+	// The draft story is flagged for missing criteria
+	if len(plan.Flagged) != 1 || plan.Flagged[0].StoryID != "draft" || plan.Flagged[0].Reason != "missing acceptance criteria" {
+		t.Errorf("Expected draft to be flagged for missing criteria, got %v", plan.Flagged)
+	}
+
+	// Each story is split into three chained tasks
+	if len(plan.Tasks) != 9 {
+		t.Fatalf("Expected 9 tasks, got %d", len(plan.Tasks))
+	}
+	if len(plan.Warnings) != 0 {
+		t.Errorf("Expected no capacity warnings, got %v", plan.Warnings)
+	}
+}
+
+func TestPlannerReplan(t *testing.T) {
+	a := newReadyStory(t, "a", 5)
+	c := newReadyStory(t, "c", 3)
+	e := newReadyStory(t, "e", 2)
+	sprint := newTestSprint()
+	p := NewPlanner(nil, forecast.History{10, 10, 10}, 2)
+	first, err := p.Plan(sprint, rankAll(a, c, e))
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	// Plan again with the first round's tasks and a new story ranked on top
+	f := newReadyStory(t, "f", 2)
+	p.Tasks = first.Tasks
+	again, err := p.Plan(sprint, rankAll(f, a, c, e))
+	if err != nil {
+		t.Fatalf("Failed to plan again: %v", err)
+	}
+
+	// Check that the committed points count first, so the new story no longer fits
+	if again.Points != 10 || len(again.Deferred) != 1 || again.Deferred[0] != "f" || len(sprint.Committed) != 3 {
+		t.Errorf("Expected 10 points with f deferred, got %d points, deferred %v, committed %v", again.Points, again.Deferred, sprint.Committed)
+	}
+
+	// Check that stories keep their tasks instead of being broken down again
+	if len(again.Tasks) != 0 || len(again.Stories) != 3 {
+		t.Fatalf("Expected 3 stories and no new tasks, got %d and %d", len(again.Stories), len(again.Tasks))
+	}
+	for i, planned := range again.Stories {
+		if len(planned.TaskIDs) != 3 || planned.TaskIDs[0] != first.Stories[i].TaskIDs[0] {
+			t.Errorf("Expected %s to keep its tasks %v, got %v", planned.StoryID, first.Stories[i].TaskIDs, planned.TaskIDs)
+		}
+	}
+}
+
+func TestPlannerPlanWithoutBounds(t *testing.T) {
+	sprint := newTestSprint()
+
+	// Without a team or history there is nothing to plan against
+	_, err := NewPlanner(nil, nil, 2).Plan(sprint, nil)
+	if !errors.Is(err, ErrNoCapacity) {
+		t.Errorf("Expected error %v, got %v", ErrNoCapacity, err)
+	}
+
+	// Sprints that already started cannot be planned
+	sprint.Status = models.SprintActive
+	_, err = NewPlanner(newTestTeam(), nil, 2).Plan(sprint, nil)
+	if !errors.Is(err, ErrSprintNotPlanned) {
+		t.Errorf("Expected error %v, got %v", ErrSprintNotPlanned, err)
+	}
+}
+
+func TestDefaultBreakdown(t *testing.T) {
+	us := newReadyStory(t, "login", 5)
+
+	tasks := DefaultBreakdown(us, 2)
+
+	// 10 hours are split 60/30/10 across a dependency chain
+	expected := []struct {
+		taskType models.TaskType
+		estimate time.Duration
+	}{
+		{models.TaskDevelopment, 6 * time.Hour},
+		{models.TaskTesting, 3 * time.Hour},
+		{models.TaskDeployment, time.Hour},
+	}
+	if len(tasks) != len(expected) {
+		t.Fatalf("Expected %d tasks, got %d", len(expected), len(tasks))
+	}
+	for i, want := range expected {
+		if tasks[i].Type != want.taskType || tasks[i].Estimate != want.estimate || tasks[i].StoryID != "login" {
+			t.Errorf("Expected task %d to be %s for %v, got %s for %v", i, want.taskType, want.estimate, tasks[i].Type, tasks[i].Estimate)
+		}
+		if i > 0 && (len(tasks[i].Dependencies) != 1 || tasks[i].Dependencies[0] != tasks[i-1].ID) {
+			t.Errorf("Expected task %d to depend on task %d, got %v", i, i-1, tasks[i].Dependencies)
+		}
+	}
+}
+
+func TestPlanStart(t *testing.T) {
+	sprint := newTestSprint()
+	plan, err := NewPlanner(nil, forecast.History{5}, 0).Plan(sprint, rankAll(newReadyStory(t, "a", 3)))
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	pub := &publisherStub{}
+	if err := plan.Start(sprint, pub); err != nil {
+		t.Fatalf("Failed to start sprint: %v", err)
+	}
+
+	// Check that the sprint is active and the plan was announced
+	if sprint.Status != models.SprintActive {
+		t.Errorf("Expected sprint to be Active, got %s", sprint.Status)
+	}
+	if len(pub.messages) != 1 || pub.messages[0].Type != communication.MsgSprintStart {
+		t.Fatalf("Expected one MsgSprintStart message, got %v", pub.messages)
+	}
+	var decoded Plan
+	if err := pub.messages[0].DecodePayload(&decoded); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if decoded.SprintID != sprint.ID || len(decoded.Stories) != 1 || len(decoded.Tasks) != 3 {
+		t.Errorf("Expected the plan as payload, got %+v", decoded)
+	}
+}
+
+func TestPlanStartFailures(t *testing.T) {
+	sprint := newTestSprint()
+	plan, err := NewPlanner(nil, forecast.History{5}, 0).Plan(sprint, rankAll(newReadyStory(t, "a", 3)))
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	// Check that the plan of another sprint is rejected
+	if err := plan.Start(newTestSprint(), &publisherStub{}); !errors.Is(err, ErrPlanMismatch) {
+		t.Errorf("Expected error %v, got %v", ErrPlanMismatch, err)
+	}
+
+	// Check that an empty plan is not announced when the sprint cannot start
+	unplanned := newTestSprint()
+	empty, err := NewPlanner(nil, forecast.History{5}, 0).Plan(unplanned, nil)
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+	pub := &publisherStub{}
+	if err := empty.Start(unplanned, pub); !errors.Is(err, models.ErrGuardFailed) {
+		t.Errorf("Expected error %v, got %v", models.ErrGuardFailed, err)
+	}
+	if len(pub.messages) != 0 {
+		t.Errorf("Expected nothing published, got %d messages", len(pub.messages))
+	}
+
+	// Check that the sprint stays in planning when the announcement fails
+	failed := errors.New("bus closed")
+	if err := plan.Start(sprint, &publisherStub{err: failed}); !errors.Is(err, failed) {
+		t.Errorf("Expected error %v, got %v", failed, err)
+	}
+	if sprint.Status != models.SprintPlanned {
+		t.Errorf("Expected sprint to stay Planned, got %s", sprint.Status)
+	}
+}
@@ -0,0 +1,260 @@
+package scrum
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+)
+
+// ErrNotCommitted is returned when reviewing a story that is not committed to the sprint
+var ErrNotCommitted = scrumError("story is not committed to the sprint")
+
+// ErrUnknownCriterion is returned when checking a criterion index the story does not have
+var ErrUnknownCriterion = scrumError("unknown acceptance criterion")
+
+// ErrUncheckedCriteria is returned when deciding on a story before every criterion was checked
+var ErrUncheckedCriteria = scrumError("acceptance criteria not checked")
+
+// ErrNoCriteria is returned when deciding on a story without acceptance criteria to check
+var ErrNoCriteria = scrumError("story has no acceptance criteria")
+
+// Verdict represents the outcome of a story's acceptance review
+type Verdict string
+
+const (
+	VerdictPending  Verdict = "Pending"
+	VerdictAccepted Verdict = "Accepted"
+	VerdictRejected Verdict = "Rejected"
+)
+
+// AcceptanceRequest is the payload of MsgAcceptanceRequest
+type AcceptanceRequest struct {
+	SprintID string   `json:"sprint_id"`
+	StoryID  string   `json:"story_id"`
+	Title    string   `json:"title"`
+	Criteria []string `json:"criteria"`
+}
+
+// RequestAcceptance asks the PO agent to review a finished story
+func RequestAcceptance(pub Publisher, sprint *models.Sprint, us *models.UserStory) error {
+	message, err := communication.NewMessage(communication.DevAgent, communication.POAgent,
+		communication.MsgAcceptanceRequest, communication.MediumPriority, AcceptanceRequest{
+			SprintID: sprint.ID,
+			StoryID:  us.ID,
+			Title:    us.Title,
+			Criteria: us.AcceptanceCriteria,
+		})
+	if err != nil {
+		return err
+	}
+	return pub.Publish(message)
+}
+
+// CriterionResult is the check of a single acceptance criterion
+type CriterionResult struct {
+	Criterion string `json:"criterion"`
+	Checked   bool   `json:"checked"`
+	Passed    bool   `json:"passed"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// Acceptance is the review of one story
+type Acceptance struct {
+	StoryID    string            `json:"story_id"`
+	Title      string            `json:"title"`
+	Points     int               `json:"points"`
+	Results    []CriterionResult `json:"results"`
+	Verdict    Verdict           `json:"verdict"`
+	Feedback   string            `json:"feedback,omitempty"` // Failed criteria and their notes
+	Rounds     int               `json:"rounds"`             // Number of decisions taken on the story
+	ReviewedAt time.Time         `json:"reviewed_at"`
+}
+
+// Review runs the acceptance of the stories committed to a sprint
+type Review struct {
+	Sprint *models.Sprint
+
+	acceptances map[string]*Acceptance // Checks of the current round
+	decisions   map[string]Acceptance  // Latest decision per story
+	order       []string
+}
+
+// NewReview starts the review of a sprint
+func NewReview(sprint *models.Sprint) *Review {
+	return &Review{Sprint: sprint, acceptances: make(map[string]*Acceptance), decisions: make(map[string]Acceptance)}
+}
+
+// acceptance returns the story's acceptance, starting one if needed
+func (r *Review) acceptance(us *models.UserStory) (*Acceptance, error) {
+	if !contains(r.Sprint.Committed, us.ID) {
+		return nil, fmt.Errorf("%w: %s", ErrNotCommitted, us.ID)
+	}
+	a, ok := r.acceptances[us.ID]
+	if !ok {
+		a = &Acceptance{StoryID: us.ID, Verdict: VerdictPending}
+		r.acceptances[us.ID] = a
+		r.order = append(r.order, us.ID)
+	}
+
+	// Keep the results in line with the story's current criteria
+	a.Title = us.Title
+	if us.Estimate != nil {
+		a.Points = us.Estimate.Points
+	}
+	for i, criterion := range us.AcceptanceCriteria {
+		if i < len(a.Results) {
+			a.Results[i].Criterion = criterion
+		} else {
+			a.Results = append(a.Results, CriterionResult{Criterion: criterion})
+		}
+	}
+	a.Results = a.Results[:len(us.AcceptanceCriteria)]
+	return a, nil
+}
+
+// Check records whether the criterion at the given index passed, with optional notes
+func (r *Review) Check(us *models.UserStory, index int, passed bool, notes string) error {
+	a, err := r.acceptance(us)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(a.Results) {
+		return fmt.Errorf("%w: %s #%d", ErrUnknownCriterion, us.ID, index)
+	}
+	a.Results[index] = CriterionResult{Criterion: a.Results[index].Criterion, Checked: true, Passed: passed, Notes: notes}
+	return nil
+}
+
+// Decide accepts the story when every criterion passed and rejects it otherwise.
+// Accepted stories move to Done and are added to the sprint's completed stories;
+// rejected stories go back to InProgress with the failed criteria as feedback.
+// Results are cleared after a rejection so the next round checks every criterion again.
+// Stories without criteria stay pending, as there is nothing to accept them against.
+func (r *Review) Decide(us *models.UserStory) (*Acceptance, error) {
+	a, err := r.acceptance(us)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.Results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoCriteria, us.ID)
+	}
+
+	var failed []string
+	for i, result := range a.Results {
+		if !result.Checked {
+			return nil, fmt.Errorf("%w: %s #%d", ErrUncheckedCriteria, us.ID, i)
+		}
+		if !result.Passed {
+			line := result.Criterion
+			if result.Notes != "" {
+				line += ": " + result.Notes
+			}
+			failed = append(failed, line)
+		}
+	}
+
+	if len(failed) == 0 {
+		if err := us.SetStatus(models.StoryDone); err != nil {
+			return nil, err
+		}
+		r.Sprint.AddCompletedStory(us.ID)
+		a.Verdict = VerdictAccepted
+		a.Feedback = ""
+	} else {
+		if err := us.SetStatus(models.StoryInProgress); err != nil {
+			return nil, err
+		}
+		r.Sprint.RemoveCompletedStory(us.ID)
+		a.Verdict = VerdictRejected
+		a.Feedback = strings.Join(failed, "\n")
+	}
+	a.Rounds++
+	a.ReviewedAt = time.Now()
+
+	result := *a
+	result.Results = append([]CriterionResult(nil), a.Results...)
+	r.decisions[us.ID] = result
+	if a.Verdict == VerdictRejected {
+		for i := range a.Results {
+			a.Results[i] = CriterionResult{Criterion: a.Results[i].Criterion}
+		}
+	}
+	return &result, nil
+}
+
+// ReviewReport summarizes the outcome of a sprint review
+type ReviewReport struct {
+	SprintID        string       `json:"sprint_id"`
+	Goal            string       `json:"goal"`
+	Accepted        []Acceptance `json:"accepted"`
+	Rejected        []Acceptance `json:"rejected"`
+	NotReviewed     []string     `json:"not_reviewed"` // Committed stories without a decision
+	CommittedPoints int          `json:"committed_points"`
+	AcceptedPoints  int          `json:"accepted_points"`
+}
+
+// Report summarizes the decisions taken so far, using the stories for committed points
+func (r *Review) Report(stories []*models.UserStory) *ReviewReport {
+	report := &ReviewReport{
+		SprintID:    r.Sprint.ID,
+		Goal:        r.Sprint.Goal,
+		Accepted:    []Acceptance{},
+		Rejected:    []Acceptance{},
+		NotReviewed: []string{},
+	}
+	points := models.StoryPoints(stories...)
+	for _, id := range r.Sprint.Committed {
+		report.CommittedPoints += points(id)
+	}
+
+	for _, id := range r.order {
+		a, ok := r.decisions[id]
+		if !ok {
+			continue
+		}
+		if a.Verdict == VerdictAccepted {
+			report.Accepted = append(report.Accepted, a)
+			report.AcceptedPoints += a.Points
+		} else {
+			report.Rejected = append(report.Rejected, a)
+		}
+	}
+	for _, id := range r.Sprint.Committed {
+		if _, ok := r.decisions[id]; !ok {
+			report.NotReviewed = append(report.NotReviewed, id)
+		}
+	}
+	return report
+}
+
+// Text renders the report as plain text
+func (report *ReviewReport) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sprint review: %s - %s\n", report.SprintID, report.Goal)
+	fmt.Fprintf(&b, "Accepted: %d points of %d committed\n", report.AcceptedPoints, report.CommittedPoints)
+
+	for _, a := range report.Accepted {
+		fmt.Fprintf(&b, "\n[Accepted] %s (%d points)\n", a.Title, a.Points)
+	}
+	for _, a := range report.Rejected {
+		fmt.Fprintf(&b, "\n[Rejected] %s (%d points)\n", a.Title, a.Points)
+		for _, result := range a.Results {
+			if result.Passed {
+				fmt.Fprintf(&b, "  pass: %s\n", result.Criterion)
+				continue
+			}
+			fmt.Fprintf(&b, "  fail: %s", result.Criterion)
+			if result.Notes != "" {
+				fmt.Fprintf(&b, " (%s)", result.Notes)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(report.NotReviewed) > 0 {
+		fmt.Fprintf(&b, "\nNot reviewed: %s\n", strings.Join(report.NotReviewed, ", "))
+	}
+	return b.String()
+}
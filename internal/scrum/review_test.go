@@ -0,0 +1,129 @@
+package scrum
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+)
+
+// newReviewStory creates an InProgress story with two criteria committed to the sprint
+func newReviewStory(t *testing.T, sprint *models.Sprint, id string, points int) *models.UserStory {
+	us := newReadyStory(t, id, points)
+	us.AddAcceptanceCriterion("Errors are shown")
+	if err := us.SetStatus(models.StoryInProgress); err != nil {
+		t.Fatalf("Failed to start %s: %v", id, err)
+	}
+	sprint.AddCommittedStory(id)
+	return us
+}
+
+func TestReviewAcceptAndReject(t *testing.T) {
+	sprint := newTestSprint()
+	login := newReviewStory(t, sprint, "login", 5)
+	signup := newReviewStory(t, sprint, "signup", 3)
+	profile := newReviewStory(t, sprint, "profile", 2)
+	r := NewReview(sprint)
+
+	// Deciding before every criterion is checked fails
+	r.Check(login, 0, true, "")
+	if _, err := r.Decide(login); !errors.Is(err, ErrUncheckedCriteria) {
+		t.Errorf("Expected error %v, got %v", ErrUncheckedCriteria, err)
+	}
+	if err := r.Check(login, 2, true, ""); !errors.Is(err, ErrUnknownCriterion) {
+		t.Errorf("Expected error %v, got %v", ErrUnknownCriterion, err)
+	}
+
+	// All criteria passing accepts the story
+	r.Check(login, 1, true, "")
+	a, err := r.Decide(login)
+	if err != nil {
+		t.Fatalf("Failed to decide: %v", err)
+	}
+	if a.Verdict != VerdictAccepted || login.Status != models.StoryDone {
+		t.Errorf("Expected login to be accepted and Done, got %s and %s", a.Verdict, login.Status)
+	}
+	if len(sprint.Completed) != 1 || sprint.Completed[0] != "login" {
+		t.Errorf("Expected login to be completed, got %v", sprint.Completed)
+	}
+
+	// A failing criterion rejects the story with feedback
+	r.Check(signup, 0, true, "")
+	r.Check(signup, 1, false, "No message on duplicate email")
+	a, err = r.Decide(signup)
+	if err != nil {
+		t.Fatalf("Failed to decide: %v", err)
+	}
+	if a.Verdict != VerdictRejected || signup.Status != models.StoryInProgress {
+		t.Errorf("Expected signup to be rejected and InProgress, got %s and %s", a.Verdict, signup.Status)
+	}
+	if a.Feedback != "Errors are shown: No message on duplicate email" {
+		t.Errorf("Expected feedback on the failed criterion, got %q", a.Feedback)
+	}
+
+	// A later rejection reopens an accepted story
+	r.Check(login, 0, false, "Regression")
+	r.Check(login, 1, true, "")
+	if _, err := r.Decide(login); err != nil {
+		t.Fatalf("Failed to decide: %v", err)
+	}
+	if login.Status != models.StoryInProgress || len(sprint.Completed) != 0 {
+		t.Errorf("Expected login to be reopened, got %s and %v", login.Status, sprint.Completed)
+	}
+
+	// Stories outside the sprint cannot be reviewed
+	other := newReadyStory(t, "other", 1)
+	if err := r.Check(other, 0, true, ""); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("Expected error %v, got %v", ErrNotCommitted, err)
+	}
+
+	report := r.Report([]*models.UserStory{login, signup, profile})
+	if len(report.Accepted) != 0 || len(report.Rejected) != 2 || report.CommittedPoints != 10 {
+		t.Errorf("Expected 2 rejected of 10 committed points, got %+v", report)
+	}
+	if len(report.NotReviewed) != 1 || report.NotReviewed[0] != "profile" {
+		t.Errorf("Expected profile not to be reviewed, got %v", report.NotReviewed)
+	}
+	if !strings.Contains(report.Text(), "fail: Errors are shown (No message on duplicate email)") {
+		t.Errorf("Expected the failed criterion in the text, got %q", report.Text())
+	}
+}
+
+func TestReviewWithoutCriteria(t *testing.T) {
+	sprint := newTestSprint()
+	us := newReviewStory(t, sprint, "login", 5)
+	us.AcceptanceCriteria = nil
+	r := NewReview(sprint)
+
+	// Check that a story without criteria is not accepted by default
+	if _, err := r.Decide(us); !errors.Is(err, ErrNoCriteria) {
+		t.Errorf("Expected error %v, got %v", ErrNoCriteria, err)
+	}
+	if us.Status != models.StoryInProgress || contains(sprint.Completed, us.ID) {
+		t.Errorf("Expected the story to stay InProgress, got %s", us.Status)
+	}
+}
+
+func TestRequestAcceptance(t *testing.T) {
+	sprint := newTestSprint()
+	us := newReviewStory(t, sprint, "login", 5)
+
+	pub := &publisherStub{}
+	if err := RequestAcceptance(pub, sprint, us); err != nil {
+		t.Fatalf("Failed to request acceptance: %v", err)
+	}
+
+	// The PO agent receives the story and its criteria
+	var request AcceptanceRequest
+	if len(pub.messages) != 1 || pub.messages[0].To != communication.POAgent {
+		t.Fatalf("Expected one message to the PO agent, got %v", pub.messages)
+	}
+	if err := pub.messages[0].DecodePayload(&request); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if request.StoryID != "login" || len(request.Criteria) != 2 {
+		t.Errorf("Expected login with 2 criteria, got %+v", request)
+	}
+}
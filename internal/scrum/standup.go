@@ -0,0 +1,300 @@
+package scrum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+)
+
+// DefaultStaleAfter is how long a task may stay InProgress before the standup flags it
+const DefaultStaleAfter = 48 * time.Hour
+
+// StandupEntry is one member's part of the daily standup
+type StandupEntry struct {
+	MemberID  string   `json:"member_id"`
+	Name      string   `json:"name,omitempty"`
+	Yesterday []string `json:"yesterday"`
+	Today     []string `json:"today"`
+	Blockers  []string `json:"blockers"`
+}
+
+// StaleTask is a task that has been InProgress longer than expected
+type StaleTask struct {
+	TaskID   string        `json:"task_id"`
+	Title    string        `json:"title"`
+	Assignee string        `json:"assignee"`
+	Since    time.Time     `json:"since"`
+	Duration time.Duration `json:"duration"`
+}
+
+// StandupSummary is the payload of MsgDailyStandup
+type StandupSummary struct {
+	SprintID string         `json:"sprint_id"`
+	Date     time.Time      `json:"date"`
+	Since    time.Time      `json:"since"` // Previous standup, zero for the first one
+	Entries  []StandupEntry `json:"entries"`
+	Stale    []StaleTask    `json:"stale,omitempty"`
+	Silent   []string       `json:"silent,omitempty"` // Members without any update or activity
+}
+
+// Blockers returns the number of blockers reported across all entries
+func (s *StandupSummary) Blockers() int {
+	n := 0
+	for _, e := range s.Entries {
+		n += len(e.Blockers)
+	}
+	return n
+}
+
+// Text renders the summary as plain text for agents and logs
+func (s *StandupSummary) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Daily standup %s - sprint %s\n", s.Date.Format("2006-01-02"), s.SprintID)
+	for _, e := range s.Entries {
+		name := e.Name
+		if name == "" {
+			name = e.MemberID
+		}
+		fmt.Fprintf(&b, "\n%s\n", name)
+		writeItems(&b, "Yesterday", e.Yesterday)
+		writeItems(&b, "Today", e.Today)
+		writeItems(&b, "Blockers", e.Blockers)
+	}
+	if len(s.Stale) > 0 {
+		b.WriteString("\nIn progress too long:\n")
+		for _, st := range s.Stale {
+			fmt.Fprintf(&b, "- %s (%s) for %.1f days\n", st.Title, st.Assignee, st.Duration.Hours()/24)
+		}
+	}
+	if len(s.Silent) > 0 {
+		fmt.Fprintf(&b, "\nNo update from: %s\n", strings.Join(s.Silent, ", "))
+	}
+	return b.String()
+}
+
+// writeItems writes a labelled list, or "none" when empty
+func writeItems(b *strings.Builder, label string, items []string) {
+	if len(items) == 0 {
+		fmt.Fprintf(b, "  %s: none\n", label)
+		return
+	}
+	fmt.Fprintf(b, "  %s:\n", label)
+	for _, item := range items {
+		fmt.Fprintf(b, "  - %s\n", item)
+	}
+}
+
+// progressUpdate is a received MsgProgressUpdate payload
+type progressUpdate struct {
+	communication.ProgressPayload
+	At time.Time
+}
+
+// Standup collects progress updates and task status changes between daily standups
+type Standup struct {
+	SprintID   string
+	Team       *models.Team     // Lists members expected to report, may be nil
+	StaleAfter time.Duration    // Defaults to DefaultStaleAfter
+	Now        func() time.Time // Defaults to time.Now
+
+	mutex           sync.Mutex
+	last            time.Time
+	updates         []progressUpdate
+	transitions     []models.Transition
+	inProgressSince map[string]time.Time
+}
+
+// NewStandup creates a standup for the sprint's team
+func NewStandup(sprintID string, team *models.Team) *Standup {
+	return &Standup{
+		SprintID:        sprintID,
+		Team:            team,
+		StaleAfter:      DefaultStaleAfter,
+		Now:             time.Now,
+		inProgressSince: make(map[string]time.Time),
+	}
+}
+
+// now returns the current time from the configured clock
+func (s *Standup) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// HandleMessage records MsgProgressUpdate messages; it can be subscribed on the agent bus
+func (s *Standup) HandleMessage(message *communication.AgentMessage) {
+	if message.Type != communication.MsgProgressUpdate {
+		return
+	}
+	var payload communication.ProgressPayload
+	if err := message.DecodePayload(&payload); err != nil {
+		return
+	}
+	if payload.MemberID == "" {
+		payload.MemberID = string(message.From)
+	}
+	at := message.Timestamp
+	if at.IsZero() {
+		at = s.now()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.updates = append(s.updates, progressUpdate{ProgressPayload: payload, At: at})
+}
+
+// Watch records task status transitions until the returned function is called.
+// Transitions are timestamped with the standup's clock.
+func (s *Standup) Watch() func() {
+	return models.OnTransition(func(t models.Transition) {
+		if t.Kind != models.KindTask {
+			return
+		}
+		t.At = s.now()
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.transitions = append(s.transitions, t)
+		if t.To == string(models.TaskInProgress) {
+			s.inProgressSince[t.EntityID] = t.At
+		} else {
+			delete(s.inProgressSince, t.EntityID)
+		}
+	})
+}
+
+// Summarize builds the standup from everything recorded since the previous standup
+// and starts a new collection period. Tasks map transitions and status to their assignees.
+func (s *Standup) Summarize(tasks []*models.DevTask) *StandupSummary {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	summary := &StandupSummary{SprintID: s.SprintID, Date: now, Since: s.last}
+
+	byID := make(map[string]*models.DevTask, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	entries := make(map[string]*StandupEntry)
+	var order []string
+	entry := func(memberID string) *StandupEntry {
+		if e, ok := entries[memberID]; ok {
+			return e
+		}
+		e := &StandupEntry{MemberID: memberID, Yesterday: []string{}, Today: []string{}, Blockers: []string{}}
+		if s.Team != nil {
+			if m, ok := s.Team.Member(memberID); ok {
+				e.Name = m.Name
+			}
+		}
+		entries[memberID] = e
+		order = append(order, memberID)
+		return e
+	}
+	if s.Team != nil {
+		for _, m := range s.Team.Members {
+			entry(m.ID)
+		}
+	}
+	active := make(map[string]bool)
+
+	// Explicit updates come first, in the order they were received
+	for _, u := range s.updates {
+		e := entry(u.MemberID)
+		active[u.MemberID] = true
+		if u.Yesterday != "" {
+			e.Yesterday = append(e.Yesterday, u.Yesterday)
+		}
+		if u.Today != "" {
+			e.Today = append(e.Today, u.Today)
+		}
+		e.Blockers = append(e.Blockers, u.Blockers...)
+	}
+
+	// Status changes of assigned tasks fill in what was done
+	for _, t := range s.transitions {
+		task, ok := byID[t.EntityID]
+		if !ok || task.Assignee == "" {
+			continue
+		}
+		active[task.Assignee] = true
+		switch models.TaskStatus(t.To) {
+		case models.TaskDone:
+			appendOnce(&entry(task.Assignee).Yesterday, "Finished "+task.Title)
+		case models.TaskInProgress:
+			appendOnce(&entry(task.Assignee).Yesterday, "Started "+task.Title)
+		}
+	}
+
+	// Current task status gives today's plan and open blockers
+	for _, task := range tasks {
+		if task.Assignee == "" {
+			continue
+		}
+		switch task.Status {
+		case models.TaskInProgress:
+			appendOnce(&entry(task.Assignee).Today, "Continue "+task.Title)
+			since, ok := s.inProgressSince[task.ID]
+			if !ok {
+				// First seen in progress without a recorded transition
+				s.inProgressSince[task.ID] = now
+				since = now
+			}
+			if d := now.Sub(since); d > s.staleAfter() {
+				summary.Stale = append(summary.Stale, StaleTask{
+					TaskID: task.ID, Title: task.Title, Assignee: task.Assignee, Since: since, Duration: d,
+				})
+			}
+		case models.TaskBlocked:
+			appendOnce(&entry(task.Assignee).Blockers, "Blocked on "+task.Title)
+		}
+	}
+	sort.SliceStable(summary.Stale, func(i, j int) bool {
+		return summary.Stale[i].Duration > summary.Stale[j].Duration
+	})
+
+	for _, id := range order {
+		summary.Entries = append(summary.Entries, *entries[id])
+		if !active[id] {
+			summary.Silent = append(summary.Silent, id)
+		}
+	}
+
+	s.last = now
+	s.updates = nil
+	s.transitions = nil
+	return summary
+}
+
+// staleAfter returns the configured stale threshold
+func (s *Standup) staleAfter() time.Duration {
+	if s.StaleAfter > 0 {
+		return s.StaleAfter
+	}
+	return DefaultStaleAfter
+}
+
+// appendOnce appends the item unless the list already holds it
+func appendOnce(list *[]string, item string) {
+	if !contains(*list, item) {
+		*list = append(*list, item)
+	}
+}
+
+// Publish sends the summary to all agents as MsgDailyStandup
+func (summary *StandupSummary) Publish(pub Publisher) error {
+	message, err := communication.NewMessage(communication.SMAgent, communication.AllAgents,
+		communication.MsgDailyStandup, communication.MediumPriority, summary)
+	if err != nil {
+		return err
+	}
+	return pub.Publish(message)
+}
@@ -0,0 +1,95 @@
+package scrum
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"egodteam/internal/communication"
+	"egodteam/internal/data/models"
+)
+
+func TestStandupSummarize(t *testing.T) {
+	team := models.NewTeam("Core")
+	alice := models.NewMember("Alice", models.RoleDeveloper)
+	bob := models.NewMember("Bob", models.RoleTester)
+	carol := models.NewMember("Carol", models.RoleDeveloper)
+	team.AddMember(alice)
+	team.AddMember(bob)
+	team.AddMember(carol)
+
+	now := time.Date(2025, 9, 24, 9, 0, 0, 0, time.UTC)
+	s := NewStandup("sprint-1", team)
+	s.Now = func() time.Time { return now }
+	stop := s.Watch()
+	defer stop()
+
+	login := &models.DevTask{ID: "login", Title: "Login form", Assignee: alice.ID, Status: models.TaskTodo}
+	api := &models.DevTask{ID: "api", Title: "Login API", Assignee: alice.ID, Status: models.TaskInProgress}
+	e2e := &models.DevTask{ID: "e2e", Title: "E2E tests", Assignee: bob.ID, Status: models.TaskTodo}
+	tasks := []*models.DevTask{login, api, e2e}
+
+	// Alice finishes the API and starts the form; Bob is blocked and says so
+	api.SetStatus(models.TaskDone)
+	login.SetStatus(models.TaskInProgress)
+	e2e.SetStatus(models.TaskBlocked)
+	message, err := communication.NewMessage(communication.DevAgent, communication.SMAgent, communication.MsgProgressUpdate,
+		communication.MediumPriority, communication.ProgressPayload{MemberID: bob.ID, Today: "Set up test data", Blockers: []string{"No staging access"}})
+	if err != nil {
+		t.Fatalf("Failed to create message: %v", err)
+	}
+	s.HandleMessage(message)
+
+	summary := s.Summarize(tasks)
+
+	// Check the entries per member, in team order
+	if len(summary.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(summary.Entries))
+	}
+	a := summary.Entries[0]
+	if a.Name != "Alice" || len(a.Yesterday) != 2 || a.Yesterday[0] != "Finished Login API" || a.Yesterday[1] != "Started Login form" {
+		t.Errorf("Expected Alice to have finished the API and started the form, got %+v", a)
+	}
+	if len(a.Today) != 1 || a.Today[0] != "Continue Login form" {
+		t.Errorf("Expected Alice to continue the form, got %v", a.Today)
+	}
+	b := summary.Entries[1]
+	if len(b.Today) != 1 || len(b.Blockers) != 2 {
+		t.Errorf("Expected Bob to have one plan and two blockers, got %+v", b)
+	}
+	if summary.Blockers() != 2 {
+		t.Errorf("Expected 2 blockers, got %d", summary.Blockers())
+	}
+
+	// Carol did nothing and is listed as silent
+	if len(summary.Silent) != 1 || summary.Silent[0] != carol.ID {
+		t.Errorf("Expected Carol to be silent, got %v", summary.Silent)
+	}
+	if !strings.Contains(summary.Text(), "No update from") {
+		t.Errorf("Expected the text to mention silent members, got %q", summary.Text())
+	}
+
+	// Three days later the form is still in progress and flagged
+	now = now.Add(72 * time.Hour)
+	summary = s.Summarize(tasks)
+	if len(summary.Stale) != 1 || summary.Stale[0].TaskID != "login" {
+		t.Fatalf("Expected login to be stale, got %v", summary.Stale)
+	}
+	if summary.Since.IsZero() || len(summary.Entries[0].Yesterday) != 0 {
+		t.Errorf("Expected a fresh period since the last standup, got %+v", summary.Entries[0])
+	}
+}
+
+func TestStandupSummaryPublish(t *testing.T) {
+	summary := NewStandup("sprint-1", nil).Summarize(nil)
+
+	pub := &publisherStub{}
+	if err := summary.Publish(pub); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+
+	// The summary is broadcast to all agents
+	if len(pub.messages) != 1 || pub.messages[0].Type != communication.MsgDailyStandup || pub.messages[0].To != communication.AllAgents {
+		t.Errorf("Expected one MsgDailyStandup to all agents, got %v", pub.messages)
+	}
+}
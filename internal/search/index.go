@@ -0,0 +1,388 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// SnippetLength is the number of runes of context shown around the first match
+const SnippetLength = 80
+
+// Field weights: a match in a title counts as much as three in a description
+const (
+	weightTitle = 3
+	weightBody  = 1
+)
+
+// Result is a document matching a search
+type Result struct {
+	Kind    models.EntityKind `json:"kind"`
+	ID      string            `json:"id"`
+	Score   float64           `json:"score"`
+	Field   string            `json:"field"`   // Field the snippet comes from
+	Snippet string            `json:"snippet"` // Field text around the matches, highlighted
+}
+
+// docKey identifies an indexed entity
+type docKey struct {
+	kind models.EntityKind
+	id   string
+}
+
+// fieldText is one indexed field of a document
+type fieldText struct {
+	name   string
+	weight float64
+	text   string
+	tokens []Token
+}
+
+// document is an indexed entity
+type document struct {
+	key    docKey
+	fields []fieldText
+	length float64 // Weighted number of tokens
+}
+
+// Index is an inverted index over stories, tasks and impediments. It is a models.Recorder:
+// tracked models record through it and it re-indexes each changed entity before passing
+// the event on. Impediments are not event sourced and are indexed with AddImpediment.
+type Index struct {
+	Pre  string // Inserted before each highlighted match, defaults to "["
+	Post string // Inserted after each highlighted match, defaults to "]"
+
+	next     models.Recorder
+	mutex    sync.RWMutex
+	state    *store.Snapshot
+	docs     map[docKey]*document
+	postings map[string]map[docKey]float64 // Weighted term frequency per document
+	total    float64                       // Sum of document lengths
+}
+
+// NewIndex creates an empty index passing recorded events on to next, which may be nil
+func NewIndex(next models.Recorder) *Index {
+	return &Index{
+		Pre:      "[",
+		Post:     "]",
+		next:     next,
+		state:    store.NewSnapshot(),
+		docs:     make(map[docKey]*document),
+		postings: make(map[string]map[docKey]float64),
+	}
+}
+
+// Record re-indexes the entity the event changed and passes the event on
+func (x *Index) Record(e models.Event) {
+	if x.next != nil {
+		x.next.Record(e)
+	}
+
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	x.state.Apply(e)
+	switch e.Kind {
+	case models.KindStory:
+		if us, ok := x.state.Stories[e.EntityID]; ok {
+			x.put(storyDocument(us))
+		}
+	case models.KindTask:
+		if dt, ok := x.state.Tasks[e.EntityID]; ok {
+			x.put(taskDocument(dt))
+		}
+	}
+}
+
+// Rebuild replaces the indexed stories and tasks by those of the snapshot, as after an
+// undo or when opening an existing event store. Impediments are kept.
+func (x *Index) Rebuild(snapshot *store.Snapshot) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	for key := range x.docs {
+		if key.kind != models.KindImpediment {
+			x.drop(key)
+		}
+	}
+	x.state = snapshot
+	for _, us := range snapshot.Stories {
+		x.put(storyDocument(us))
+	}
+	for _, dt := range snapshot.Tasks {
+		x.put(taskDocument(dt))
+	}
+}
+
+// AddImpediment indexes the impediment, replacing any earlier version of it
+func (x *Index) AddImpediment(im *models.Impediment) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	x.put(impedimentDocument(im))
+}
+
+// RemoveImpediment removes the impediment from the index
+func (x *Index) RemoveImpediment(id string) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	x.drop(docKey{models.KindImpediment, id})
+}
+
+// Len returns the number of indexed documents
+func (x *Index) Len() int {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return len(x.docs)
+}
+
+// storyDocument builds the document of a story
+func storyDocument(us *models.UserStory) *document {
+	return newDocument(docKey{models.KindStory, us.ID},
+		fieldText{name: "title", weight: weightTitle, text: us.Title},
+		fieldText{name: "description", weight: weightBody, text: us.Description},
+		fieldText{name: "criteria", weight: weightBody, text: strings.Join(us.AcceptanceCriteria, "\n")},
+	)
+}
+
+// taskDocument builds the document of a task
+func taskDocument(dt *models.DevTask) *document {
+	return newDocument(docKey{models.KindTask, dt.ID},
+		fieldText{name: "title", weight: weightTitle, text: dt.Title},
+	)
+}
+
+// impedimentDocument builds the document of an impediment
+func impedimentDocument(im *models.Impediment) *document {
+	var actions []string
+	actions = append(actions, im.Actions.Immediate...)
+	actions = append(actions, im.Actions.ShortTerm...)
+	actions = append(actions, im.Actions.LongTerm...)
+	return newDocument(docKey{models.KindImpediment, im.ID},
+		fieldText{name: "description", weight: weightTitle, text: im.Description},
+		fieldText{name: "impact", weight: weightBody, text: im.Impact},
+		fieldText{name: "root_cause", weight: weightBody, text: im.RootCause},
+		fieldText{name: "actions", weight: weightBody, text: strings.Join(actions, "\n")},
+		fieldText{name: "resolution", weight: weightBody, text: im.Resolution},
+	)
+}
+
+// newDocument tokenizes the fields of a document
+func newDocument(key docKey, fields ...fieldText) *document {
+	d := &document{key: key}
+	for _, f := range fields {
+		if f.text == "" {
+			continue
+		}
+		f.tokens = Tokenize(f.text)
+		d.length += f.weight * float64(len(f.tokens))
+		d.fields = append(d.fields, f)
+	}
+	return d
+}
+
+// put replaces the document in the index; the caller must hold the write lock
+func (x *Index) put(d *document) {
+	x.drop(d.key)
+	x.docs[d.key] = d
+	x.total += d.length
+	for _, f := range d.fields {
+		for _, t := range f.tokens {
+			postings, ok := x.postings[t.Term]
+			if !ok {
+				postings = make(map[docKey]float64)
+				x.postings[t.Term] = postings
+			}
+			postings[d.key] += f.weight
+		}
+	}
+}
+
+// drop removes the document from the index; the caller must hold the write lock
+func (x *Index) drop(key docKey) {
+	d, ok := x.docs[key]
+	if !ok {
+		return
+	}
+	for _, f := range d.fields {
+		for _, t := range f.tokens {
+			delete(x.postings[t.Term], key)
+			if len(x.postings[t.Term]) == 0 {
+				delete(x.postings, t.Term)
+			}
+		}
+	}
+	x.total -= d.length
+	delete(x.docs, key)
+}
+
+// Search returns the documents containing every term of the query, best first, limited
+// to the given kinds if any and to limit results if positive. Scores use BM25 over the
+// weighted fields.
+func (x *Index) Search(query string, limit int, kinds ...models.EntityKind) []Result {
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	// Start from the rarest term to keep the candidate set small
+	sort.SliceStable(terms, func(i, j int) bool {
+		return len(x.postings[terms[i]]) < len(x.postings[terms[j]])
+	})
+	var candidates []docKey
+	for key := range x.postings[terms[0]] {
+		if len(kinds) > 0 && !hasKind(kinds, key.kind) {
+			continue
+		}
+		candidates = append(candidates, key)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	n := float64(len(x.docs))
+	average := x.total / n
+	var results []Result
+next:
+	for _, key := range candidates {
+		d := x.docs[key]
+		score := 0.0
+		for _, term := range terms {
+			tf, ok := x.postings[term][key]
+			if !ok {
+				continue next
+			}
+			df := float64(len(x.postings[term]))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			score += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*d.length/average))
+		}
+
+		field, snippet := x.snippet(d, terms)
+		results = append(results, Result{Kind: key.kind, ID: key.id, Score: score, Field: field, Snippet: snippet})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Kind != results[j].Kind {
+			return results[i].Kind < results[j].Kind
+		}
+		return results[i].ID < results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// hasKind reports whether the kind is in the list
+func hasKind(kinds []models.EntityKind, kind models.EntityKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// snippet picks the field with the most weighted matches and highlights the matches
+// within SnippetLength runes starting a little before the first one
+func (x *Index) snippet(d *document, terms []string) (string, string) {
+	wanted := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		wanted[t] = true
+	}
+
+	var best *fieldText
+	var bestSpans []Token
+	bestScore := 0.0
+	for i := range d.fields {
+		f := &d.fields[i]
+		var spans []Token
+		for _, t := range f.tokens {
+			if wanted[t.Term] {
+				spans = append(spans, t)
+			}
+		}
+		if score := f.weight * float64(len(spans)); score > bestScore {
+			best, bestSpans, bestScore = f, spans, score
+		}
+	}
+	if best == nil {
+		return "", ""
+	}
+
+	// Merge overlapping spans, such as the pairs of a CJK word
+	merged := []Token{bestSpans[0]}
+	for _, s := range bestSpans[1:] {
+		last := &merged[len(merged)-1]
+		if s.Start <= last.End {
+			if s.End > last.End {
+				last.End = s.End
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	runes := []rune(best.text)
+	start := merged[0].Start - SnippetLength/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + SnippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	// Do not cut words in half; CJK text can be cut anywhere
+	for start > 0 && start < merged[0].Start && inWord(runes, start) {
+		start++
+	}
+	for end < len(runes) && end > merged[0].End && inWord(runes, end) {
+		end--
+	}
+
+	var body strings.Builder
+	pos := start
+	for _, s := range merged {
+		if s.Start >= end {
+			break
+		}
+		if s.End > end {
+			s.End = end
+		}
+		body.WriteString(string(runes[pos:s.Start]))
+		body.WriteString(x.Pre)
+		body.WriteString(string(runes[s.Start:s.End]))
+		body.WriteString(x.Post)
+		pos = s.End
+	}
+	body.WriteString(string(runes[pos:end]))
+
+	snippet := strings.Join(strings.Fields(body.String()), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return best.name, snippet
+}
+
+// inWord reports whether position i falls between two word characters
+func inWord(runes []rune, i int) bool {
+	return classify(runes[i-1]) == classWord && classify(runes[i]) == classWord
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"testing"
+
+	"egodteam/internal/data/models"
+	"egodteam/internal/data/store"
+)
+
+func TestIndexTracksModelChanges(t *testing.T) {
+	events := store.New()
+	index := NewIndex(events)
+
+	login := models.NewUserStory("用户登录", "作为用户，我想要使用密码登录，以便访问我的账户")
+	login.Track(index)
+	login.AddAcceptanceCriterion("Given a registered user, when they enter the password, then they see the dashboard")
+	export := models.NewUserStory("Export report", "As a manager, I want to export the report as CSV")
+	export.Track(index)
+	task := models.NewDevTask(login.ID, "实现登录接口", "dev-1")
+	task.Track(index)
+
+	// Check that the events still reach the store
+	if n := len(events.Events()); n != 4 {
+		t.Errorf("Expected 4 events in the store, got %d", n)
+	}
+
+	// Check a Chinese search over the story title and the task
+	results := index.Search("登录", 0)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results for 登录, got %+v", results)
+	}
+	for _, r := range results {
+		if r.ID == login.ID && (r.Kind != models.KindStory || r.Field != "title" || r.Snippet != "用户[登录]") {
+			t.Errorf("Expected the story title with 登录 highlighted, got %+v", r)
+		}
+	}
+
+	// Check that all terms must match and kinds filter the results
+	if results := index.Search("登录 password", 0); len(results) != 1 || results[0].ID != login.ID {
+		t.Errorf("Expected only the login story, got %+v", results)
+	}
+	if results := index.Search("登录", 0, models.KindTask); len(results) != 1 || results[0].ID != task.ID {
+		t.Errorf("Expected only the task, got %+v", results)
+	}
+
+	// Check that changes are re-indexed
+	export.Update("Export 报表", export.Description, export.Priority, export.BusinessValue)
+	if results := index.Search("report", 0); len(results) != 1 || results[0].Field != "description" {
+		t.Errorf("Expected the old title to be gone and the description to match, got %+v", results)
+	}
+	if results := index.Search("报表", 0); len(results) != 1 || results[0].ID != export.ID {
+		t.Errorf("Expected the new title to match, got %+v", results)
+	}
+}
+
+func TestIndexSnippetAndRanking(t *testing.T) {
+	index := NewIndex(nil)
+	index.Pre, index.Post = "<b>", "</b>"
+
+	long := models.NewUserStory("Dashboard",
+		"As an admin I want a dashboard listing every service with its latest deploy, owner, on-call rotation and the deploy history so that I can audit the deploy process")
+	long.Track(index)
+	short := models.NewUserStory("Deploy button", "Deploy from the UI")
+	short.Track(index)
+
+	results := index.Search("deploy", 0)
+
+	// Check that the title match ranks first
+	if len(results) != 2 || results[0].ID != short.ID {
+		t.Fatalf("Expected the deploy button first, got %+v", results)
+	}
+
+	// Check that long fields are trimmed around the first match and every match is highlighted
+	want := "…with its latest <b>deploy</b>, owner, on-call rotation and the <b>deploy</b> history so…"
+	if results[1].Snippet != want {
+		t.Errorf("Expected snippet %q, got %q", want, results[1].Snippet)
+	}
+
+	// Check the limit
+	if results := index.Search("deploy", 1); len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+}
+
+func TestIndexImpedimentsAndRebuild(t *testing.T) {
+	events := store.New()
+	index := NewIndex(events)
+
+	im := models.NewImpediment("dev-1", "测试环境数据库连接超时", models.UrgencyHigh)
+	index.AddImpediment(im)
+	us := models.NewUserStory("Checkout", "Pay for the cart")
+	us.Track(index)
+
+	// Check that impediments are searchable
+	if results := index.Search("数据库", 0); len(results) != 1 || results[0].Kind != models.KindImpediment {
+		t.Errorf("Expected the impediment, got %+v", results)
+	}
+
+	// Check that re-adding picks up edits
+	im.Analyze(models.CategoryTechnical, models.ScopeSprint, "All testing stops", "Connection pool too small")
+	index.AddImpediment(im)
+	if results := index.Search("pool", 0); len(results) != 1 || results[0].Field != "root_cause" {
+		t.Errorf("Expected a root cause match, got %+v", results)
+	}
+
+	// Check that a rebuild after undo drops the story and keeps the impediment
+	if err := events.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	index.Rebuild(events.State())
+	if results := index.Search("checkout", 0); len(results) != 0 {
+		t.Errorf("Expected the undone story to be gone, got %+v", results)
+	}
+	if index.Len() != 1 {
+		t.Errorf("Expected only the impediment to remain, got %d documents", index.Len())
+	}
+}
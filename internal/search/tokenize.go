@@ -0,0 +1,85 @@
+// Package search provides an embedded full-text index over stories, tasks and impediments
+// with CJK-aware tokenization, ranked results and highlighted snippets.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token is a term found in a text with its position in runes
+type Token struct {
+	Term  string
+	Start int // First rune
+	End   int // One past the last rune
+}
+
+// class is the kind of character a rune is for tokenizing
+type class int
+
+const (
+	classSpace class = iota
+	classWord
+	classCJK
+)
+
+// classify returns the class of a rune
+func classify(r rune) class {
+	switch {
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+		return classCJK
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return classWord
+	}
+	return classSpace
+}
+
+// Tokenize splits a text into lowercased words and, since CJK text has no spaces, every
+// single character and every pair of adjacent characters of CJK runs
+func Tokenize(text string) []Token {
+	return tokenize(text, true)
+}
+
+// queryTerms tokenizes a query. CJK runs of two or more characters use only their pairs,
+// which must all be present, so a word like 用户登录 matches as a phrase.
+func queryTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, t := range tokenize(text, false) {
+		if !seen[t.Term] {
+			seen[t.Term] = true
+			terms = append(terms, t.Term)
+		}
+	}
+	return terms
+}
+
+// tokenize emits word tokens and CJK n-grams; unigrams are only emitted for runs of
+// one character unless all is set
+func tokenize(text string, all bool) []Token {
+	var tokens []Token
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		c := classify(runes[i])
+		j := i + 1
+		for j < len(runes) && classify(runes[j]) == c {
+			j++
+		}
+
+		switch c {
+		case classWord:
+			tokens = append(tokens, Token{Term: strings.ToLower(string(runes[i:j])), Start: i, End: j})
+		case classCJK:
+			for k := i; k < j; k++ {
+				if all || j-i == 1 {
+					tokens = append(tokens, Token{Term: string(runes[k]), Start: k, End: k + 1})
+				}
+				if k+1 < j {
+					tokens = append(tokens, Token{Term: string(runes[k : k+2]), Start: k, End: k + 2})
+				}
+			}
+		}
+		i = j
+	}
+	return tokens
+}
@@ -0,0 +1,44 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+// terms returns the terms of the tokens joined by spaces
+func terms(tokens []Token) string {
+	var out []string
+	for _, t := range tokens {
+		out = append(out, t.Term)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestTokenize(t *testing.T) {
+	cases := map[string]string{
+		"Export the CSV report":  "export the csv report",
+		"用户登录":                   "用 用户 户 户登 登 登录 录",
+		"支持CSV导出, v2":            "支 支持 持 csv 导 导出 出 v2",
+		"ログイン page":              "ロ ログ グ グイ イ イン ン page",
+		"  -- ":                  "",
+		"dev_2 finished task-12": "dev_2 finished task 12",
+	}
+	for text, want := range cases {
+		if got := terms(Tokenize(text)); got != want {
+			t.Errorf("Expected %q to give %q, got %q", text, want, got)
+		}
+	}
+
+	// Check that positions are in runes
+	tokens := Tokenize("支持CSV")
+	if last := tokens[len(tokens)-1]; last.Term != "csv" || last.Start != 2 || last.End != 5 {
+		t.Errorf("Expected csv at runes 2-5, got %+v", last)
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	// Check that CJK words keep only their pairs and single characters stay whole
+	if got := strings.Join(queryTerms("用户登录 登录 CSV 录"), " "); got != "用户 户登 登录 csv 录" {
+		t.Errorf("Expected deduplicated pairs, got %q", got)
+	}
+}